@@ -75,14 +75,32 @@ type ExternalDNSSpec struct {
 	// ExternalDNS will be configured to create
 	// DNS records for.
 	//
-	// Multiple ExternalDNS CRs must be
-	// created if multiple ExternalDNS source resources
-	// are desired.
+	// The hostname annotation policy and the FQDN templates
+	// of the source apply to the additional sources too.
 	//
 	// +kubebuilder:validation:Required
 	// +required
 	Source ExternalDNSSource `json:"source"`
 
+	// AdditionalSources describes the source resources
+	// ExternalDNS will be configured to create DNS records for
+	// in addition to the one given in the source field.
+	// All the sources are served by the same ExternalDNS instance,
+	// hence they share the same TXT record owner.
+	//
+	// Each source type can be given only once across
+	// the source and the additional sources.
+	// Only one label filter can be specified across all the sources,
+	// it applies to the objects of all the source types.
+	//
+	// The source types and options introduced in v1beta1 version
+	// can only be given using v1beta1 version.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=7
+	// +optional
+	AdditionalSources []ExternalDNSSourceUnion `json:"additionalSources,omitempty"`
+
 	// Zones describes which DNS Zone IDs
 	// ExternalDNS should publish records to.
 	//
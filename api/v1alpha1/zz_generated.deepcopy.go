@@ -369,6 +369,13 @@ func (in *ExternalDNSSpec) DeepCopyInto(out *ExternalDNSSpec) {
 	}
 	in.Provider.DeepCopyInto(&out.Provider)
 	in.Source.DeepCopyInto(&out.Source)
+	if in.AdditionalSources != nil {
+		in, out := &in.AdditionalSources, &out.AdditionalSources
+		*out = make([]ExternalDNSSourceUnion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Zones != nil {
 		in, out := &in.Zones, &out.Zones
 		*out = make([]string, len(*in))
@@ -27,6 +27,10 @@ import (
 // +kubebuilder:resource:path=externaldnses,scope=Cluster,singular=externaldns
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Zones",type=string,JSONPath=`.status.zoneStatuses[*].zone`,priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type=string,JSONPath=`.status.zoneStatuses[*].lastSyncTime`,priority=1
+// +kubebuilder:printcolumn:name="Records",type=string,JSONPath=`.status.zoneStatuses[*].managedRecords`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // ExternalDNS describes a managed ExternalDNS controller instance for a cluster.
 // The controller is responsible for creating external DNS records in supported
@@ -76,14 +80,29 @@ type ExternalDNSSpec struct {
 	// ExternalDNS will be configured to create
 	// DNS records for.
 	//
-	// Multiple ExternalDNS CRs must be
-	// created if multiple ExternalDNS source resources
-	// are desired.
+	// The hostname annotation policy and the FQDN templates
+	// of the source apply to the additional sources too.
 	//
 	// +kubebuilder:validation:Required
 	// +required
 	Source ExternalDNSSource `json:"source"`
 
+	// AdditionalSources describes the source resources
+	// ExternalDNS will be configured to create DNS records for
+	// in addition to the one given in the source field.
+	// All the sources are served by the same ExternalDNS instance,
+	// hence they share the same TXT record owner.
+	//
+	// Each source type can be given only once across
+	// the source and the additional sources.
+	// Only one label filter can be specified across all the sources,
+	// it applies to the objects of all the source types.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=7
+	// +optional
+	AdditionalSources []ExternalDNSSourceUnion `json:"additionalSources,omitempty"`
+
 	// Zones describes which DNS Zone IDs
 	// ExternalDNS should publish records to.
 	//
@@ -97,6 +116,11 @@ type ExternalDNSSpec struct {
 	// lookup a default set of zones e.g on OpenShift with its cluster
 	// DNS config
 	//
+	// On OpenShift the credentials requested from the Cloud Credential Operator
+	// are limited to these zones for AWS provider only.
+	// The Azure and GCP credentials allow the changes in all the zones
+	// of the resource group or the project, the zones are limited by ExternalDNS only.
+	//
 	// +kubebuilder:validation:MaxItems=10
 	// +kubebuilder:validation:Optional
 	// +optional
@@ -111,6 +135,355 @@ type ExternalDNSSpec struct {
 	// +kubebuilder:validation:Maximum=3600
 	// +optional
 	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+
+	// Policy specifies which changes ExternalDNS is allowed
+	// to make to the DNS records it owns.
+	//
+	// The following values are accepted:
+	//
+	//  "Sync": The records are created, updated and deleted
+	//  to match the sources.
+	//  "UpsertOnly": The records are created and updated but never deleted.
+	//  Useful during migrations: a wrong source filter cannot mass-delete records.
+	//  "CreateOnly": The records are only created, neither updated nor deleted.
+	//
+	// The default value is "Sync".
+	//
+	// +kubebuilder:default:=Sync
+	// +kubebuilder:validation:Optional
+	// +optional
+	Policy ExternalDNSPolicy `json:"policy,omitempty"`
+
+	// DryRun makes ExternalDNS compute the changes to the DNS records
+	// without applying them. The planned changes are summarized
+	// in the status conditions and listed in the "external-dns-<name>-plan"
+	// configmap of the operand namespace.
+	// DryRun cannot be enabled together with the CleanupRecords deletion policy.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// OperandNamespace is the namespace in which the ExternalDNS
+	// deployment, service account and credentials secret are created.
+	// The namespace must exist, it's not created by the operator.
+	// When unset, the operand namespace of the operator is used.
+	//
+	// The field cannot be changed after creation.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	OperandNamespace string `json:"operandNamespace,omitempty"`
+
+	// DeletionPolicy specifies what happens to the DNS records
+	// managed by ExternalDNS when the ExternalDNS instance is deleted.
+	//
+	// The following values are accepted:
+	//
+	//  "Retain": The DNS records are left in the zones.
+	//  "CleanupRecords": The operator runs a one-shot job
+	//  with the provider configuration of the instance which removes
+	//  all the DNS records owned by the instance before the deletion completes.
+	//  The result of the cleanup is reported in the status conditions.
+	//  Cannot be used together with DryRun.
+	//
+	// The default value is "Retain".
+	//
+	// +kubebuilder:default:=Retain
+	// +kubebuilder:validation:Optional
+	// +optional
+	DeletionPolicy ExternalDNSDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Metrics describes how the metrics of ExternalDNS are exposed.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Metrics ExternalDNSMetrics `json:"metrics,omitempty"`
+
+	// Registry describes how ExternalDNS keeps track
+	// of the DNS records it owns.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Registry ExternalDNSRegistry `json:"registry,omitempty"`
+
+	// Deployment describes the overrides of the resources
+	// and the scheduling of ExternalDNS pods.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Deployment ExternalDNSDeploymentOptions `json:"deployment,omitempty"`
+
+	// Suspend scales the ExternalDNS deployment down to zero replicas
+	// while keeping all the resources generated for the instance.
+	// The DNS records are left as they are until the instance is resumed.
+	// The suspension is reported in the "Suspended" status condition,
+	// the conditions about the availability of the deployment are not reported.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Logging describes the logs of ExternalDNS.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Logging ExternalDNSLogging `json:"logging,omitempty"`
+}
+
+// ExternalDNSLogging describes the logs of ExternalDNS.
+type ExternalDNSLogging struct {
+	// Level is the minimum severity of the logged messages.
+	//
+	// The following values are accepted:
+	//
+	//  "Debug": Every change of the DNS records is logged.
+	//  "Info": The synchronization of the DNS records is logged.
+	//  "Warn": Only the warnings and the errors are logged.
+	//  "Error": Only the errors are logged.
+	//
+	// The "Warn" and "Error" levels are raised to "Info" while the dry run mode
+	// or the TXT owner migration is active.
+	//
+	// The default value is "Debug".
+	//
+	// +kubebuilder:default:=Debug
+	// +kubebuilder:validation:Optional
+	// +optional
+	Level ExternalDNSLogLevel `json:"level,omitempty"`
+
+	// Format is the format of the logged messages.
+	//
+	// The following values are accepted:
+	//
+	//  "Text": The messages are logged as plain text.
+	//  "JSON": The messages are logged as JSON objects.
+	//
+	// The default value is "Text".
+	//
+	// +kubebuilder:default:=Text
+	// +kubebuilder:validation:Optional
+	// +optional
+	Format ExternalDNSLogFormat `json:"format,omitempty"`
+}
+
+// ExternalDNSDeploymentOptions describes the overrides of the resources
+// and the scheduling of ExternalDNS pods.
+// The scheduling overrides apply to the pods of the cleanup job too.
+type ExternalDNSDeploymentOptions struct {
+	// Resources describes the compute resources of the containers.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Resources ExternalDNSContainerResources `json:"resources,omitempty"`
+
+	// NodeSelector is the node selector of the pods.
+	// Replaces the default node selector which schedules
+	// the pods on the Linux nodes ("kubernetes.io/os: linux").
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are the tolerations of the pods.
+	// Replace the default toleration of the master nodes
+	// ("node-role.kubernetes.io/master" with "NoSchedule" effect).
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity is the affinity of the pods.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the pods.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// TopologySpreadConstraints describes how the pods
+	// are spread across the topology domains.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// Labels are the extra labels of the pods.
+	// The "app.kubernetes.io/name" and "app.kubernetes.io/instance" labels
+	// are reserved for the pod selector and cannot be given.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are the extra annotations of the pods.
+	// The annotations with "externaldns.olm.openshift.io/" prefix
+	// are reserved for the operator and cannot be given.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ExternalDNSContainerResources describes the compute resources
+// of the containers of ExternalDNS pods.
+type ExternalDNSContainerResources struct {
+	// ExternalDNS is the compute resources of every ExternalDNS container,
+	// one container is run per zone.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	ExternalDNS *corev1.ResourceRequirements `json:"externalDNS,omitempty"`
+
+	// MetricsProxy is the compute resources of every kube-rbac-proxy container
+	// which exposes the metrics of ExternalDNS.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	MetricsProxy *corev1.ResourceRequirements `json:"metricsProxy,omitempty"`
+
+	// WebhookProvider is the compute resources of the webhook provider container.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	WebhookProvider *corev1.ResourceRequirements `json:"webhookProvider,omitempty"`
+}
+
+// ExternalDNSMetrics describes how the metrics of ExternalDNS are exposed.
+type ExternalDNSMetrics struct {
+	// Exposure specifies how the metrics endpoints
+	// of ExternalDNS containers are exposed.
+	//
+	// The following values are accepted:
+	//
+	//  "Local": The metrics are bound to the loopback interface
+	//  of the pod and cannot be scraped from outside of it.
+	//  "KubeRBACProxy": The metrics of every zone are served on the pod IP
+	//  by a kube-rbac-proxy sidecar which authenticates and authorizes the scrapers.
+	//  The operator creates a service and a monitoring.coreos.com ServiceMonitor
+	//  (if the CRD is installed) for the metrics endpoints.
+	//
+	// The default value is "Local".
+	//
+	// +kubebuilder:default:=Local
+	// +kubebuilder:validation:Optional
+	// +optional
+	Exposure ExternalDNSMetricsExposure `json:"exposure,omitempty"`
+}
+
+// ExternalDNSRegistry describes how ExternalDNS keeps track
+// of the DNS records it owns.
+type ExternalDNSRegistry struct {
+	// Type is the type of the registry.
+	//
+	// The following values are accepted:
+	//
+	//  "TXT": The ownership of every DNS record is stored
+	//  in a companion TXT record.
+	//  "Noop": The ownership is not tracked, ExternalDNS considers
+	//  all the records of the zones as its own. Useful for the zones
+	//  which forbid TXT records. Can only be used with "UpsertOnly"
+	//  or "CreateOnly" policy and "Retain" deletion policy.
+	//
+	// The default value is "TXT".
+	//
+	// +kubebuilder:default:=TXT
+	// +kubebuilder:validation:Optional
+	// +optional
+	Type ExternalDNSRegistryType `json:"type,omitempty"`
+
+	// TXT describes the options of the TXT registry.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	TXT *ExternalDNSTXTRegistryOptions `json:"txt,omitempty"`
+}
+
+// ExternalDNSTXTRegistryOptions describes the options of the TXT registry.
+type ExternalDNSTXTRegistryOptions struct {
+	// OwnerID is the identifier of ExternalDNS instance
+	// stored in the TXT records. The records with a different
+	// owner ID are never changed by the instance.
+	// Useful to adopt the records created by another ExternalDNS.
+	//
+	// The default value is "external-dns-<name>".
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	OwnerID string `json:"ownerID,omitempty"`
+
+	// MigrateFromOwnerID is the owner ID of the TXT records to take over.
+	// ExternalDNS considers the records with the given owner ID as its own
+	// and gradually rewrites their TXT records with OwnerID.
+	// The progress is reported by the "OwnerMigration" status condition,
+	// the field can be removed once the migration is completed.
+	// Useful to rename ExternalDNS instance without orphaning its records:
+	// the owner ID of the renamed instance is "external-dns-<old name>".
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	MigrateFromOwnerID string `json:"migrateFromOwnerID,omitempty"`
+
+	// Prefix is prepended to the name of the DNS record
+	// to get the name of its TXT record.
+	// Cannot be specified together with Suffix.
+	//
+	// The default value is "external-dns-" unless Suffix is specified.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix is appended to the first label of the name of the DNS record
+	// to get the name of its TXT record.
+	// Cannot be specified together with Prefix.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Suffix string `json:"suffix,omitempty"`
+
+	// WildcardReplacement is the string which replaces
+	// the wildcard in the names of the TXT records of the wildcard DNS records.
+	//
+	// When omitted, the wildcard is not replaced
+	// except for Azure provider which uses "any".
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	WildcardReplacement string `json:"wildcardReplacement,omitempty"`
+
+	// Encryption enables the encryption of the values of the TXT records.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Encryption *ExternalDNSTXTRegistryEncryption `json:"encryption,omitempty"`
+}
+
+// ExternalDNSTXTRegistryEncryption describes the encryption of the TXT records.
+type ExternalDNSTXTRegistryEncryption struct {
+	// AESKeySecretKey is the key of the credentials secret
+	// whose value is the AES-256 key which encrypts the values of the TXT records.
+	// The key must be 32 bytes long.
+	// The encryption requires the credentials secret to be referenced by the provider,
+	// it cannot be enabled with the credentials requested from the Cloud Credential Operator
+	// or with the credentials modes which don't use any secret.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	AESKeySecretKey string `json:"aesKeySecretKey"`
 }
 
 // ExternalDNSDomain describes how sets of included
@@ -211,6 +584,9 @@ type ExternalDNSProvider struct {
 	//  * Azure
 	//  * BlueCat
 	//  * Infoblox
+	//  * Cloudflare
+	//  * RFC2136
+	//  * Webhook
 	//
 	// +kubebuilder:validation:Required
 	// +unionDiscriminator
@@ -251,6 +627,27 @@ type ExternalDNSProvider struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	Infoblox *ExternalDNSInfobloxProviderOptions `json:"infoblox,omitempty"`
+
+	// Cloudflare describes provider configuration options
+	// specific to Cloudflare DNS.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Cloudflare *ExternalDNSCloudflareProviderOptions `json:"cloudflare,omitempty"`
+
+	// RFC2136 describes provider configuration options
+	// specific to the DNS servers supporting dynamic updates (RFC2136).
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	RFC2136 *ExternalDNSRFC2136ProviderOptions `json:"rfc2136,omitempty"`
+
+	// Webhook describes provider configuration options
+	// specific to the providers implemented as ExternalDNS webhooks.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Webhook *ExternalDNSWebhookProviderOptions `json:"webhook,omitempty"`
 }
 
 type ExternalDNSAWSProviderOptions struct {
@@ -260,6 +657,9 @@ type ExternalDNSAWSProviderOptions struct {
 	// * aws_access_key_id
 	// * aws_secret_access_key
 	//
+	// The keys are not used in "WebIdentity" credentials mode,
+	// the secret is optional in this mode.
+	//
 	// +kubebuilder:validation:Required
 	// +kubebuilder:default:={"name":""}
 	// +required
@@ -272,6 +672,52 @@ type ExternalDNSAWSProviderOptions struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	AssumeRole *ExternalDNSAWSAssumeRoleOptions `json:"assumeRole,omitempty"`
+
+	// CredentialsMode specifies how ExternalDNS authenticates to AWS.
+	//
+	// The following values are accepted:
+	//
+	//  "Secret": The access keys are taken from the credentials secret
+	//  (or from the one provisioned by the cloud credentials operator on OpenShift).
+	//  "WebIdentity": The service account token of the pod is exchanged
+	//  for the temporary credentials of the IAM role given in webIdentity field.
+	//  No long-lived access keys are needed.
+	//
+	// The default value is "Secret".
+	//
+	// +kubebuilder:default:=Secret
+	// +kubebuilder:validation:Optional
+	// +optional
+	CredentialsMode ExternalDNSAWSCredentialsMode `json:"credentialsMode,omitempty"`
+
+	// WebIdentity describes the IAM role assumed with the web identity
+	// of the pod. Required in "WebIdentity" credentials mode.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	WebIdentity *ExternalDNSAWSWebIdentityOptions `json:"webIdentity,omitempty"`
+}
+
+// ExternalDNSAWSWebIdentityOptions describes the IAM role
+// assumed with the projected service account token of ExternalDNS pod.
+type ExternalDNSAWSWebIdentityOptions struct {
+	// RoleARN is the ARN of the IAM role assumed with the web identity.
+	// The trust policy of the role must allow the service account
+	// of ExternalDNS instance ("external-dns-<name>").
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	RoleARN string `json:"roleARN"`
+
+	// Audience is the intended audience of the projected service account token.
+	// Must match the audience of the OIDC identity provider configured in IAM.
+	//
+	// The default value is "sts.amazonaws.com".
+	//
+	// +kubebuilder:default:=sts.amazonaws.com
+	// +kubebuilder:validation:Optional
+	// +optional
+	Audience string `json:"audience,omitempty"`
 }
 
 type ExternalDNSGCPProviderOptions struct {
@@ -290,9 +736,90 @@ type ExternalDNSGCPProviderOptions struct {
 	// contain a key named `gcp-credentials.json`
 	// presumably generated by the gcloud CLI.
 	//
+	// Must not be specified in "WorkloadIdentityFederation" and "GKEWorkloadIdentity" credentials modes.
+	//
 	// +kubebuilder:validation:Required
+	// +kubebuilder:default:={"name":""}
 	// +required
 	Credentials SecretReference `json:"credentials"`
+
+	// CredentialsMode specifies how ExternalDNS authenticates to GCP.
+	//
+	// The following values are accepted:
+	//
+	//  "Secret": The service account key is taken from the credentials secret
+	//  (or from the one provisioned by the cloud credentials operator on OpenShift).
+	//  "WorkloadIdentityFederation": The service account token of the pod is exchanged
+	//  for the access token by the workload identity pool provider given in workloadIdentityFederation field.
+	//  The operator renders the external account credential config pointing at the token.
+	//  "GKEWorkloadIdentity": The access token of the GCP service account given in gkeWorkloadIdentity field
+	//  is taken from the GKE metadata server. The service account of ExternalDNS instance is annotated with it.
+	//
+	// No service account key is needed in the workload identity modes.
+	//
+	// The default value is "Secret".
+	//
+	// +kubebuilder:default:=Secret
+	// +kubebuilder:validation:Optional
+	// +optional
+	CredentialsMode ExternalDNSGCPCredentialsMode `json:"credentialsMode,omitempty"`
+
+	// WorkloadIdentityFederation describes the workload identity pool provider
+	// which trusts the service account tokens of the cluster.
+	// Required in "WorkloadIdentityFederation" credentials mode.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	WorkloadIdentityFederation *ExternalDNSGCPWorkloadIdentityFederationOptions `json:"workloadIdentityFederation,omitempty"`
+
+	// GKEWorkloadIdentity describes the GCP service account which
+	// the service account of ExternalDNS instance acts as on GKE.
+	// Required in "GKEWorkloadIdentity" credentials mode.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	GKEWorkloadIdentity *ExternalDNSGCPGKEWorkloadIdentityOptions `json:"gkeWorkloadIdentity,omitempty"`
+}
+
+// ExternalDNSGCPWorkloadIdentityFederationOptions describes the workload identity pool provider
+// which exchanges the projected service account token of ExternalDNS pod.
+type ExternalDNSGCPWorkloadIdentityFederationOptions struct {
+	// Audience is the full resource name of the workload identity pool provider:
+	// "//iam.googleapis.com/projects/<project-number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>".
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Audience string `json:"audience"`
+
+	// TokenAudience is the intended audience of the projected service account token.
+	// Must be one of the allowed audiences of the workload identity pool provider.
+	//
+	// When omitted, the default allowed audience of the provider is used:
+	// the audience prefixed with "https:".
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	TokenAudience string `json:"tokenAudience,omitempty"`
+
+	// ServiceAccountEmail is the email of the GCP service account impersonated
+	// with the federated token. When omitted, the federated identity
+	// is granted the access to the DNS zones directly.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	ServiceAccountEmail string `json:"serviceAccountEmail,omitempty"`
+}
+
+// ExternalDNSGCPGKEWorkloadIdentityOptions describes the GCP service account
+// which ExternalDNS acts as on GKE.
+type ExternalDNSGCPGKEWorkloadIdentityOptions struct {
+	// ServiceAccountEmail is the email of the GCP service account.
+	// The service account must allow the service account of ExternalDNS instance
+	// ("external-dns-<name>") to act as it.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	ServiceAccountEmail string `json:"serviceAccountEmail"`
 }
 
 type ExternalDNSAzureProviderOptions struct {
@@ -313,9 +840,77 @@ type ExternalDNSAzureProviderOptions struct {
 	// https://github.com/kubernetes-sigs/external-dns/blob/226dbb931f7a2019810b3703aec096c4ea4f40ea/docs/tutorials/azure.md#configuration-file
 	// for more information on the necessary configuration key/values and how to obtain them.
 	//
+	// Must not be specified in "WorkloadIdentity" credentials mode,
+	// the config file is rendered by the operator in this mode.
+	//
 	// +kubebuilder:validation:Required
+	// +kubebuilder:default:={"name":""}
 	// +required
 	ConfigFile SecretReference `json:"configFile"`
+
+	// CredentialsMode specifies how ExternalDNS authenticates to Azure.
+	//
+	// The following values are accepted:
+	//
+	//  "Secret": The service principal is taken from the config file secret
+	//  (or from the one provisioned by the cloud credentials operator on OpenShift).
+	//  "WorkloadIdentity": The service account token of the pod is exchanged
+	//  for the access token of the managed identity or the application given in workloadIdentity field.
+	//  No client secret is needed.
+	//
+	// The default value is "Secret".
+	//
+	// +kubebuilder:default:=Secret
+	// +kubebuilder:validation:Optional
+	// +optional
+	CredentialsMode ExternalDNSAzureCredentialsMode `json:"credentialsMode,omitempty"`
+
+	// WorkloadIdentity describes the identity federated with the service account
+	// of ExternalDNS instance. Required in "WorkloadIdentity" credentials mode.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	WorkloadIdentity *ExternalDNSAzureWorkloadIdentityOptions `json:"workloadIdentity,omitempty"`
+}
+
+// ExternalDNSAzureWorkloadIdentityOptions describes the identity
+// which ExternalDNS pod authenticates as with the projected service account token.
+type ExternalDNSAzureWorkloadIdentityOptions struct {
+	// ClientID is the client ID of the managed identity or the application.
+	// The identity must have the federated credential which trusts
+	// the service account of ExternalDNS instance ("external-dns-<name>").
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	ClientID string `json:"clientID"`
+
+	// TenantID is the ID of the tenant of the identity.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	TenantID string `json:"tenantID"`
+
+	// SubscriptionID is the ID of the subscription of the DNS zones.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	SubscriptionID string `json:"subscriptionID"`
+
+	// ResourceGroup is the resource group of the DNS zones.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	ResourceGroup string `json:"resourceGroup"`
+
+	// Audience is the intended audience of the projected service account token.
+	// Must match the audience of the federated credential.
+	//
+	// The default value is "api://AzureADTokenExchange".
+	//
+	// +kubebuilder:default:="api://AzureADTokenExchange"
+	// +kubebuilder:validation:Optional
+	// +optional
+	Audience string `json:"audience,omitempty"`
 }
 
 type ExternalDNSBlueCatProviderOptions struct {
@@ -382,6 +977,193 @@ type ExternalDNSInfobloxProviderOptions struct {
 	MaxResults int `json:"maxResults,omitempty"`
 }
 
+type ExternalDNSCloudflareProviderOptions struct {
+	// Credentials is a reference to a secret containing
+	// the following key (with proper corresponding value):
+	//
+	// * CF_API_TOKEN
+	//
+	// The API token needs "Zone:Read" and "DNS:Edit" permissions.
+	// Note that the zones are filtered by the Cloudflare zone IDs
+	// given in the zones field of the ExternalDNS spec.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Credentials SecretReference `json:"credentials"`
+
+	// Proxied specifies whether or not the records
+	// are created with the Cloudflare proxy enabled by default.
+	// The default can be overridden per source resource with
+	// the "external-dns.alpha.kubernetes.io/cloudflare-proxied" annotation.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Proxied bool `json:"proxied,omitempty"`
+}
+
+type ExternalDNSRFC2136ProviderOptions struct {
+	// Credentials is a reference to a secret containing
+	// the following key (with proper corresponding value):
+	//
+	// * EXTERNAL_DNS_RFC2136_TSIG_SECRET
+	//
+	// If GSS-TSIG is enabled the secret should contain
+	// the following keys instead:
+	//
+	// * EXTERNAL_DNS_RFC2136_KERBEROS_USERNAME
+	// * EXTERNAL_DNS_RFC2136_KERBEROS_PASSWORD
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Credentials SecretReference `json:"credentials"`
+
+	// Host is the host of the DNS server.
+	//
+	// e.g. "dns.example.com" or "192.168.1.53"
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Host string `json:"host"`
+
+	// Port is the port of the DNS server.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default:=53
+	// +optional
+	Port int `json:"port,omitempty"`
+
+	// Zone is the DNS zone to be updated.
+	// Note that the zones field of the ExternalDNS spec
+	// cannot be used with the RFC2136 provider.
+	//
+	// e.g. "example.com"
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Zone string `json:"zone"`
+
+	// TSIGKeyName is the name of the TSIG key
+	// used to sign the updates.
+	// It must be specified unless GSS-TSIG is enabled.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	TSIGKeyName string `json:"tsigKeyName,omitempty"`
+
+	// TSIGSecretAlgorithm is the algorithm of the TSIG key.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=hmac-md5;hmac-sha1;hmac-sha224;hmac-sha256;hmac-sha384;hmac-sha512
+	// +optional
+	TSIGSecretAlgorithm string `json:"tsigSecretAlgorithm,omitempty"`
+
+	// TSIGAXFR specifies whether or not the zone transfers (AXFR)
+	// are used to list the existing records. The DNS server
+	// must allow the zone transfers signed with the same key.
+	// Only the records created by ExternalDNS in the current run
+	// are known if the zone transfers are disabled.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	TSIGAXFR bool `json:"tsigAXFR,omitempty"`
+
+	// GSSTSIG enables GSS-TSIG (Kerberos) authentication
+	// instead of TSIG, as required by Windows DNS servers.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	GSSTSIG *ExternalDNSRFC2136GSSTSIGOptions `json:"gssTSIG,omitempty"`
+}
+
+type ExternalDNSRFC2136GSSTSIGOptions struct {
+	// KerberosRealm is the Kerberos realm
+	// used for the authentication.
+	//
+	// e.g. "EXAMPLE.COM"
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	KerberosRealm string `json:"kerberosRealm"`
+}
+
+type ExternalDNSWebhookProviderOptions struct {
+	// Credentials is a reference to a secret containing
+	// the credentials of the webhook provider.
+	// The keys of the secret can be passed to the webhook provider
+	// as environment variables using the env field.
+	//
+	// +kubebuilder:validation:Required
+	// +required
+	Credentials SecretReference `json:"credentials"`
+
+	// Image is the image of the webhook provider.
+	// The webhook provider is run as a sidecar container
+	// next to the ExternalDNS container.
+	//
+	// e.g. "ghcr.io/ionos-cloud/external-dns-ionos-webhook:v0.6.1"
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Image string `json:"image"`
+
+	// Args are the arguments of the webhook provider container.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env is the list of the environment variables
+	// of the webhook provider container.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Env []ExternalDNSWebhookProviderEnvVar `json:"env,omitempty"`
+
+	// Port is the port on which the webhook provider listens.
+	// ExternalDNS reaches the webhook provider on localhost,
+	// the port must match the one configured in the webhook provider.
+	// The port is checked by the startup and readiness probes of the sidecar
+	// on the pod IP: the webhook provider must listen on all the interfaces of the pod.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default:=8888
+	// +optional
+	Port int `json:"port,omitempty"`
+}
+
+// ExternalDNSWebhookProviderEnvVar represents an environment variable
+// of the webhook provider container.
+// Either value or secretKey must be specified.
+type ExternalDNSWebhookProviderEnvVar struct {
+	// Name is the name of the environment variable.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Value is the value of the environment variable.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// SecretKey is the key of the credentials secret
+	// whose value is used as the value of the environment variable.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	SecretKey string `json:"secretKey,omitempty"`
+}
+
 // SecretReference contains the information to let you locate the desired secret.
 // Secret is required to be in the operator namespace.
 type SecretReference struct {
@@ -392,15 +1174,18 @@ type SecretReference struct {
 	Name string `json:"name"`
 }
 
-// +kubebuilder:validation:Enum=AWS;GCP;Azure;BlueCat;Infoblox
+// +kubebuilder:validation:Enum=AWS;GCP;Azure;BlueCat;Infoblox;Cloudflare;RFC2136;Webhook
 type ExternalDNSProviderType string
 
 const (
-	ProviderTypeAWS      ExternalDNSProviderType = "AWS"
-	ProviderTypeGCP      ExternalDNSProviderType = "GCP"
-	ProviderTypeAzure    ExternalDNSProviderType = "Azure"
-	ProviderTypeBlueCat  ExternalDNSProviderType = "BlueCat"
-	ProviderTypeInfoblox ExternalDNSProviderType = "Infoblox"
+	ProviderTypeAWS        ExternalDNSProviderType = "AWS"
+	ProviderTypeGCP        ExternalDNSProviderType = "GCP"
+	ProviderTypeAzure      ExternalDNSProviderType = "Azure"
+	ProviderTypeBlueCat    ExternalDNSProviderType = "BlueCat"
+	ProviderTypeInfoblox   ExternalDNSProviderType = "Infoblox"
+	ProviderTypeCloudflare ExternalDNSProviderType = "Cloudflare"
+	ProviderTypeRFC2136    ExternalDNSProviderType = "RFC2136"
+	ProviderTypeWebhook    ExternalDNSProviderType = "Webhook"
 	// More providers will ultimately be added in the future.
 )
 
@@ -434,9 +1219,10 @@ type ExternalDNSSource struct {
 	// Multiple global FQDN templates are possible.
 	//
 	// This field must be specified with a nonempty value if the source type
-	// is Service or CRD and HostnameAnnotationPolicy is set to Ignore.  The
+	// is Service and HostnameAnnotationPolicy is set to Ignore.  The
 	// field value may be omitted or empty if HostnameAnnotationPolicy is
-	// set to Allow or if the source type is OpenShiftRoute.
+	// set to Allow or if the source type is OpenShiftRoute, Ingress, CRD or one of
+	// the Gateway API route types.
 	//
 	// Provided templates should follow the syntax defined for text/template Go package,
 	// see https://pkg.go.dev/text/template.
@@ -483,15 +1269,47 @@ type ExternalDNSSourceUnion struct {
 	// +kubebuilder:validation:Optional
 	// +optional
 	OpenShiftRoute *ExternalDNSOpenShiftRouteOptions `json:"openshiftRouteOptions,omitempty"`
+
+	// CRD describes source configuration options specific
+	// to the CRD source resource.
+	//
+	// When omitted for the CRD source type, ExternalDNS is configured
+	// to consume the DNSEndpoint resources (externaldns.k8s.io/v1alpha1)
+	// shipped with the operator.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	CRD *ExternalDNSCRDSourceOptions `json:"crd,omitempty"`
+
+	// Gateway describes source configuration options specific
+	// to the Gateway API route sources (GatewayHTTPRoute, GatewayGRPCRoute,
+	// GatewayTLSRoute, GatewayTCPRoute and GatewayUDPRoute).
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Gateway *ExternalDNSGatewayRouteSourceOptions `json:"gateway,omitempty"`
+
+	// Ingress describes source configuration options specific
+	// to the ingresses.networking.k8s.io resource.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	Ingress *ExternalDNSIngressSourceOptions `json:"ingress,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=OpenShiftRoute;Service;CRD
+// +kubebuilder:validation:Enum=OpenShiftRoute;Service;Ingress;CRD;GatewayHTTPRoute;GatewayGRPCRoute;GatewayTLSRoute;GatewayTCPRoute;GatewayUDPRoute
 type ExternalDNSSourceType string
 
 const (
-	SourceTypeRoute   ExternalDNSSourceType = "OpenShiftRoute"
-	SourceTypeService ExternalDNSSourceType = "Service"
-	SourceTypeCRD     ExternalDNSSourceType = "CRD"
+	SourceTypeRoute            ExternalDNSSourceType = "OpenShiftRoute"
+	SourceTypeService          ExternalDNSSourceType = "Service"
+	SourceTypeIngress          ExternalDNSSourceType = "Ingress"
+	SourceTypeCRD              ExternalDNSSourceType = "CRD"
+	SourceTypeGatewayHTTPRoute ExternalDNSSourceType = "GatewayHTTPRoute"
+	SourceTypeGatewayGRPCRoute ExternalDNSSourceType = "GatewayGRPCRoute"
+	SourceTypeGatewayTLSRoute  ExternalDNSSourceType = "GatewayTLSRoute"
+	SourceTypeGatewayTCPRoute  ExternalDNSSourceType = "GatewayTCPRoute"
+	SourceTypeGatewayUDPRoute  ExternalDNSSourceType = "GatewayUDPRoute"
 )
 
 // +kubebuilder:validation:Enum=Ignore;Allow
@@ -502,6 +1320,82 @@ const (
 	HostnameAnnotationPolicyAllow  HostnameAnnotationPolicy = "Allow"
 )
 
+// +kubebuilder:validation:Enum=Sync;UpsertOnly;CreateOnly
+type ExternalDNSPolicy string
+
+const (
+	PolicySync       ExternalDNSPolicy = "Sync"
+	PolicyUpsertOnly ExternalDNSPolicy = "UpsertOnly"
+	PolicyCreateOnly ExternalDNSPolicy = "CreateOnly"
+)
+
+// +kubebuilder:validation:Enum=Retain;CleanupRecords
+type ExternalDNSDeletionPolicy string
+
+const (
+	DeletionPolicyRetain         ExternalDNSDeletionPolicy = "Retain"
+	DeletionPolicyCleanupRecords ExternalDNSDeletionPolicy = "CleanupRecords"
+)
+
+// +kubebuilder:validation:Enum=Local;KubeRBACProxy
+type ExternalDNSMetricsExposure string
+
+const (
+	MetricsExposureLocal         ExternalDNSMetricsExposure = "Local"
+	MetricsExposureKubeRBACProxy ExternalDNSMetricsExposure = "KubeRBACProxy"
+)
+
+// +kubebuilder:validation:Enum=Secret;WebIdentity
+type ExternalDNSAWSCredentialsMode string
+
+const (
+	AWSCredentialsModeSecret      ExternalDNSAWSCredentialsMode = "Secret"
+	AWSCredentialsModeWebIdentity ExternalDNSAWSCredentialsMode = "WebIdentity"
+)
+
+// +kubebuilder:validation:Enum=Secret;WorkloadIdentity
+type ExternalDNSAzureCredentialsMode string
+
+const (
+	AzureCredentialsModeSecret           ExternalDNSAzureCredentialsMode = "Secret"
+	AzureCredentialsModeWorkloadIdentity ExternalDNSAzureCredentialsMode = "WorkloadIdentity"
+)
+
+// +kubebuilder:validation:Enum=Secret;WorkloadIdentityFederation;GKEWorkloadIdentity
+type ExternalDNSGCPCredentialsMode string
+
+const (
+	GCPCredentialsModeSecret                     ExternalDNSGCPCredentialsMode = "Secret"
+	GCPCredentialsModeWorkloadIdentityFederation ExternalDNSGCPCredentialsMode = "WorkloadIdentityFederation"
+	GCPCredentialsModeGKEWorkloadIdentity        ExternalDNSGCPCredentialsMode = "GKEWorkloadIdentity"
+)
+
+// +kubebuilder:validation:Enum=Debug;Info;Warn;Error
+type ExternalDNSLogLevel string
+
+const (
+	LogLevelDebug ExternalDNSLogLevel = "Debug"
+	LogLevelInfo  ExternalDNSLogLevel = "Info"
+	LogLevelWarn  ExternalDNSLogLevel = "Warn"
+	LogLevelError ExternalDNSLogLevel = "Error"
+)
+
+// +kubebuilder:validation:Enum=Text;JSON
+type ExternalDNSLogFormat string
+
+const (
+	LogFormatText ExternalDNSLogFormat = "Text"
+	LogFormatJSON ExternalDNSLogFormat = "JSON"
+)
+
+// +kubebuilder:validation:Enum=TXT;Noop
+type ExternalDNSRegistryType string
+
+const (
+	RegistryTypeTXT  ExternalDNSRegistryType = "TXT"
+	RegistryTypeNoop ExternalDNSRegistryType = "Noop"
+)
+
 type ExternalDNSAWSAssumeRoleOptions struct {
 	// arn is an IAM role ARN that the ExternalDNS
 	// operator will assume when making DNS updates.
@@ -541,6 +1435,40 @@ type ExternalDNSServiceSourceOptions struct {
 	ServiceType []corev1.ServiceType `json:"serviceType,omitempty"`
 }
 
+// ExternalDNSIngressSourceOptions describes options
+// specific to the ExternalDNS ingress source.
+type ExternalDNSIngressSourceOptions struct {
+	// IngressClassNames limits the ingresses
+	// watched by ExternalDNS to the given ingress classes.
+	// The class is matched against the ingress's
+	// spec.ingressClassName field or the legacy
+	// "kubernetes.io/ingress.class" annotation.
+	// All ingresses are watched if omitted.
+	//
+	// e.g. "nginx"
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:MinLength=1
+	// +optional
+	IngressClassNames []string `json:"ingressClassNames,omitempty"`
+
+	// IgnoreTLSSpec specifies whether or not ExternalDNS
+	// should ignore the hosts from the ingress's spec.tls section.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	IgnoreTLSSpec bool `json:"ignoreTLSSpec,omitempty"`
+
+	// IgnoreRulesSpec specifies whether or not ExternalDNS
+	// should ignore the hosts from the ingress's spec.rules section.
+	// Only the hosts from the hostname annotation and
+	// the spec.tls section are published if set.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	IgnoreRulesSpec bool `json:"ignoreRulesSpec,omitempty"`
+}
+
 type ExternalDNSOpenShiftRouteOptions struct {
 	// RouterName is the name of a router (AKA ingress controller) as
 	// reported in Route.status.ingress[].routerName.  External-dns will use
@@ -593,6 +1521,40 @@ type ExternalDNSCRDSourceOptions struct {
 	LabelFilter *metav1.LabelSelector `json:"labelFilter,omitempty"`
 }
 
+// ExternalDNSGatewayRouteSourceOptions describes options
+// specific to the ExternalDNS Gateway API route sources.
+// Records are published for the hostnames of the routes
+// which are attached to the Gateways matching the given filters.
+type ExternalDNSGatewayRouteSourceOptions struct {
+	// GatewayNamespace limits the Gateways
+	// the routes must be attached to, to the given namespace.
+	// Gateways from all namespaces are considered if omitted.
+	//
+	// e.g. "openshift-ingress"
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+
+	// GatewayLabelFilter specifies a label selector
+	// to be used to filter the Gateways
+	// the routes must be attached to.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	GatewayLabelFilter *metav1.LabelSelector `json:"gatewayLabelFilter,omitempty"`
+
+	// RouteLabelFilter specifies a label filter
+	// to be used to filter the route instances.
+	// Only one of the source label filter and
+	// the route label filter can be specified on
+	// an ExternalDNS instance.
+	//
+	// +kubebuilder:validation:Optional
+	// +optional
+	RouteLabelFilter *metav1.LabelSelector `json:"routeLabelFilter,omitempty"`
+}
+
 // ExternalDNSStatus defines the observed state of ExternalDNS.
 type ExternalDNSStatus struct {
 	// Conditions is a list of operator-specific conditions
@@ -604,6 +1566,47 @@ type ExternalDNSStatus struct {
 
 	// Zones is the configured zones in use by ExternalDNS.
 	Zones []string `json:"zones,omitempty"`
+
+	// ZoneStatuses is the synchronization status of the zones,
+	// one entry per ExternalDNS container.
+	// The last synchronization time and the number of the managed records
+	// are reported only if the metrics are exposed by kube-rbac-proxy.
+	//
+	// +optional
+	// +listType=atomic
+	ZoneStatuses []ExternalDNSZoneStatus `json:"zoneStatuses,omitempty"`
+}
+
+// ExternalDNSZoneStatus describes the synchronization status
+// of the zone served by a single ExternalDNS container.
+type ExternalDNSZoneStatus struct {
+	// Zone is the ID of the zone.
+	// Empty if the container publishes to all the zones.
+	//
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// ContainerName is the name of ExternalDNS container which serves the zone.
+	ContainerName string `json:"containerName"`
+
+	// LastSyncTime is the time of the last successful synchronization
+	// of the DNS records with the zone.
+	//
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ManagedRecords is the number of the DNS records
+	// owned by ExternalDNS in the zone.
+	//
+	// +optional
+	ManagedRecords *int64 `json:"managedRecords,omitempty"`
+
+	// LastError is the last error which prevented
+	// the synchronization of the zone or the reporting
+	// of the last sync time and the managed records.
+	//
+	// +optional
+	LastError string `json:"lastError,omitempty"`
 }
 
 var (
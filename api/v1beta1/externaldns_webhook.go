@@ -17,15 +17,19 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	utilErrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -36,8 +40,24 @@ var webhookLog = logf.Log.WithName("validating-webhook")
 
 var isOpenShift bool
 
-func (r *ExternalDNS) SetupWebhookWithManager(mgr ctrl.Manager, openshift bool) error {
+var (
+	// gcpWorkloadIdentityPoolProviderRegexp matches the full resource name of GCP workload identity pool provider
+	gcpWorkloadIdentityPoolProviderRegexp = regexp.MustCompile(`^//iam\.googleapis\.com/projects/[0-9]+/locations/global/workloadIdentityPools/[a-z0-9-]+/providers/[a-z0-9-]+$`)
+	// gcpServiceAccountEmailRegexp matches the email of GCP service account
+	gcpServiceAccountEmailRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]@[a-z0-9-:.]+\.iam\.gserviceaccount\.com$`)
+)
+
+// webhookReader is used to look up the other ExternalDNS instances,
+// the overlaps are not detected if nil.
+var webhookReader client.Reader
+
+// allowedOperandNamespaces are the namespaces which can be given as the operand namespace.
+var allowedOperandNamespaces []string
+
+func (r *ExternalDNS) SetupWebhookWithManager(mgr ctrl.Manager, openshift bool, operandNamespaces []string) error {
 	isOpenShift = openshift
+	allowedOperandNamespaces = operandNamespaces
+	webhookReader = mgr.GetAPIReader()
 	webhookLog.Info("Setting up the webhook", "IsOpenShift", isOpenShift)
 	return ctrl.NewWebhookManagedBy(mgr).For(r).Complete()
 }
@@ -51,13 +71,27 @@ var _ webhook.Validator = &ExternalDNS{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *ExternalDNS) ValidateCreate() (admission.Warnings, error) {
 	webhookLog.Info("validate create", "name", r.Name)
-	return nil, r.validate(nil)
+	// the operand namespace cannot be changed, it's checked only at the creation:
+	// the updates of the existing instances are not blocked if the namespace is not allowed anymore
+	if err := utilErrors.NewAggregate([]error{
+		r.validate(),
+		r.validateOperandNamespace(),
+	}); err != nil {
+		return nil, err
+	}
+	return r.overlapWarnings(), nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *ExternalDNS) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	webhookLog.Info("validate update", "name", r.Name)
-	return nil, r.validate(old)
+	if err := utilErrors.NewAggregate([]error{
+		r.validate(),
+		r.validateOperandNamespaceUpdate(old),
+	}); err != nil {
+		return nil, err
+	}
+	return r.overlapWarnings(), nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -66,34 +100,113 @@ func (r *ExternalDNS) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
-func (r *ExternalDNS) validate(old runtime.Object) error {
+func (r *ExternalDNS) validate() error {
 	return utilErrors.NewAggregate([]error{
 		r.validateFilters(),
-		r.validateSources(old),
+		r.validateSources(),
 		r.validateHostnameAnnotationPolicy(),
 		r.validateProviderCredentials(),
 		r.validateAWSRoleARN(),
+		r.validateAWSWebIdentity(),
+		r.validateAzureWorkloadIdentity(),
+		r.validateGCPWorkloadIdentity(),
+		r.validatePolicy(),
+		r.validateRegistry(),
+		r.validateDeletionPolicy(),
+		r.validateDeployment(),
 	})
 }
 
-func (r *ExternalDNS) validateSources(old runtime.Object) error {
-	if old != nil {
-		if oldR, ok := old.(*ExternalDNS); ok {
-			if oldR.Spec.Source.Type == SourceTypeCRD {
-				// allow the update if the resource was created
-				// before the CRD source validation was introduced
-				return nil
-			}
+func (r *ExternalDNS) validateOperandNamespace() error {
+	if r.Spec.OperandNamespace == "" || slices.Contains(allowedOperandNamespaces, r.Spec.OperandNamespace) {
+		return nil
+	}
+	return fmt.Errorf("operand namespace %q is not allowed, the allowed namespaces are given by the --allowed-operand-namespaces flag of the operator", r.Spec.OperandNamespace)
+}
+
+func (r *ExternalDNS) validateOperandNamespaceUpdate(old runtime.Object) error {
+	oldExtDNS, ok := old.(*ExternalDNS)
+	if !ok {
+		return nil
+	}
+	if oldExtDNS.Spec.OperandNamespace != r.Spec.OperandNamespace {
+		return errors.New(`"operandNamespace" cannot be changed after creation`)
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateSources() error {
+	sources := r.sources()
+	seen := map[ExternalDNSSourceType]bool{}
+	labelFilters, gatewayOptions := 0, 0
+	for _, source := range sources {
+		if seen[source.Type] {
+			return fmt.Errorf("source type %q can be specified only once", source.Type)
+		}
+		seen[source.Type] = true
+
+		if err := validateSource(source); err != nil {
+			return err
+		}
+
+		if source.LabelFilter != nil || (source.CRD != nil && source.CRD.LabelFilter != nil) || (source.Gateway != nil && source.Gateway.RouteLabelFilter != nil) {
+			labelFilters++
 		}
+		if source.Gateway != nil {
+			gatewayOptions++
+		}
+	}
+
+	if labelFilters > 1 {
+		return errors.New("only one label filter can be specified across all the sources")
 	}
-	switch r.Spec.Source.Type {
-	case SourceTypeCRD:
-		return errors.New("CRD source is not implemented")
+	if gatewayOptions > 1 {
+		return errors.New(`"gateway" options can be specified only for one of the sources`)
 	}
 
 	return nil
 }
 
+func validateSource(source ExternalDNSSourceUnion) error {
+	if source.Type == SourceTypeCRD && source.CRD != nil {
+		if source.CRD.Kind == "" || source.CRD.Version == "" {
+			return errors.New(`"kind" and "version" must be specified when CRD source options are given`)
+		}
+		if source.LabelFilter != nil && source.CRD.LabelFilter != nil {
+			return errors.New(`only one of "labelFilter" and "crd.labelFilter" can be specified`)
+		}
+	}
+
+	if source.Ingress != nil && source.Type != SourceTypeIngress {
+		return fmt.Errorf(`"ingress" options cannot be specified for %q source type`, source.Type)
+	}
+
+	if source.Gateway != nil {
+		if !isGatewayRouteSource(source.Type) {
+			return fmt.Errorf(`"gateway" options cannot be specified for %q source type`, source.Type)
+		}
+		if source.LabelFilter != nil && source.Gateway.RouteLabelFilter != nil {
+			return errors.New(`only one of "labelFilter" and "gateway.routeLabelFilter" can be specified`)
+		}
+	}
+
+	return nil
+}
+
+// sources returns the source and the additional sources of the ExternalDNS.
+func (r *ExternalDNS) sources() []ExternalDNSSourceUnion {
+	return append([]ExternalDNSSourceUnion{r.Spec.Source.ExternalDNSSourceUnion}, r.Spec.AdditionalSources...)
+}
+
+// isGatewayRouteSource returns true if the given source type is one of the Gateway API route types.
+func isGatewayRouteSource(sourceType ExternalDNSSourceType) bool {
+	switch sourceType {
+	case SourceTypeGatewayHTTPRoute, SourceTypeGatewayGRPCRoute, SourceTypeGatewayTLSRoute, SourceTypeGatewayTCPRoute, SourceTypeGatewayUDPRoute:
+		return true
+	}
+	return false
+}
+
 func (r *ExternalDNS) validateFilters() error {
 	for _, f := range r.Spec.Domains {
 		switch f.MatchType {
@@ -118,13 +231,14 @@ func (r *ExternalDNS) validateFilters() error {
 }
 
 func (r *ExternalDNS) validateHostnameAnnotationPolicy() error {
-	if r.Spec.Source.Type == SourceTypeRoute {
-		// dummy fqdnTemplate is used for Route source
-		return nil
-	}
-
-	if r.Spec.Source.HostnameAnnotationPolicy == HostnameAnnotationPolicyIgnore && len(r.Spec.Source.FQDNTemplate) == 0 {
-		return errors.New(`"fqdnTemplate" must be specified when "hostnameAnnotation" is "Ignore"`)
+	for _, source := range r.sources() {
+		// dummy fqdnTemplate is used for Route, Ingress, CRD and Gateway API route sources
+		if source.Type != SourceTypeService {
+			continue
+		}
+		if r.Spec.Source.HostnameAnnotationPolicy == HostnameAnnotationPolicyIgnore && len(r.Spec.Source.FQDNTemplate) == 0 {
+			return errors.New(`"fqdnTemplate" must be specified when "hostnameAnnotation" is "Ignore"`)
+		}
 	}
 	return nil
 }
@@ -136,14 +250,23 @@ func (r *ExternalDNS) validateProviderCredentials() error {
 	provider := r.Spec.Provider
 	switch provider.Type {
 	case ProviderTypeAWS:
+		if provider.AWS != nil && provider.AWS.CredentialsMode == AWSCredentialsModeWebIdentity {
+			return nil
+		}
 		if provider.AWS == nil || provider.AWS.Credentials.Name == "" {
 			return errors.New("credentials secret must be specified when provider type is AWS")
 		}
 	case ProviderTypeAzure:
+		if provider.Azure != nil && provider.Azure.CredentialsMode == AzureCredentialsModeWorkloadIdentity {
+			return nil
+		}
 		if provider.Azure == nil || provider.Azure.ConfigFile.Name == "" {
 			return errors.New("config file name must be specified when provider type is Azure")
 		}
 	case ProviderTypeGCP:
+		if provider.GCP != nil && provider.GCP.CredentialsMode != "" && provider.GCP.CredentialsMode != GCPCredentialsModeSecret {
+			return nil
+		}
 		if provider.GCP == nil || provider.GCP.Credentials.Name == "" {
 			return errors.New("credentials secret must be specified when provider type is GCP")
 		}
@@ -155,6 +278,32 @@ func (r *ExternalDNS) validateProviderCredentials() error {
 		if provider.Infoblox == nil || provider.Infoblox.WAPIVersion == "" || provider.Infoblox.WAPIPort == 0 || provider.Infoblox.GridHost == "" || provider.Infoblox.Credentials.Name == "" {
 			return errors.New(`"WAPIVersion", "WAPIPort", "GridHost" and credentials file must be specified when provider is Infoblox`)
 		}
+	case ProviderTypeCloudflare:
+		if provider.Cloudflare == nil || provider.Cloudflare.Credentials.Name == "" {
+			return errors.New("credentials secret must be specified when provider type is Cloudflare")
+		}
+	case ProviderTypeRFC2136:
+		if provider.RFC2136 == nil || provider.RFC2136.Host == "" || provider.RFC2136.Zone == "" || provider.RFC2136.Credentials.Name == "" {
+			return errors.New(`"host", "zone" and credentials secret must be specified when provider type is RFC2136`)
+		}
+		if provider.RFC2136.GSSTSIG == nil && provider.RFC2136.TSIGKeyName == "" {
+			return errors.New(`"tsigKeyName" must be specified when GSS-TSIG is not enabled for RFC2136 provider`)
+		}
+		if len(r.Spec.Zones) > 0 {
+			return errors.New(`"zones" cannot be specified when provider type is RFC2136, use "rfc2136.zone" instead`)
+		}
+	case ProviderTypeWebhook:
+		if provider.Webhook == nil || provider.Webhook.Image == "" || provider.Webhook.Credentials.Name == "" {
+			return errors.New(`"image" and credentials secret must be specified when provider type is Webhook`)
+		}
+		for _, env := range provider.Webhook.Env {
+			if (env.Value == "") == (env.SecretKey == "") {
+				return fmt.Errorf(`exactly one of "value" or "secretKey" must be specified for webhook provider env variable %q`, env.Name)
+			}
+		}
+		if len(r.Spec.Zones) > 0 {
+			return errors.New(`"zones" cannot be specified when provider type is Webhook, zones are filtered by the webhook provider`)
+		}
 	}
 	return nil
 }
@@ -168,3 +317,301 @@ func (r *ExternalDNS) validateAWSRoleARN() error {
 
 	return nil
 }
+
+func (r *ExternalDNS) validateAWSWebIdentity() error {
+	aws := r.Spec.Provider.AWS
+	if aws == nil {
+		return nil
+	}
+	if aws.CredentialsMode != AWSCredentialsModeWebIdentity {
+		if aws.WebIdentity != nil {
+			return errors.New(`"webIdentity" can only be specified in "WebIdentity" credentials mode`)
+		}
+		return nil
+	}
+	if aws.WebIdentity == nil || !arn.IsARN(aws.WebIdentity.RoleARN) {
+		return errors.New(`"webIdentity.roleARN" must be a valid AWS ARN in "WebIdentity" credentials mode`)
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateAzureWorkloadIdentity() error {
+	azure := r.Spec.Provider.Azure
+	if azure == nil {
+		return nil
+	}
+	if azure.CredentialsMode != AzureCredentialsModeWorkloadIdentity {
+		if azure.WorkloadIdentity != nil {
+			return errors.New(`"workloadIdentity" can only be specified in "WorkloadIdentity" credentials mode`)
+		}
+		return nil
+	}
+	if azure.ConfigFile.Name != "" {
+		return errors.New(`"configFile" cannot be specified in "WorkloadIdentity" credentials mode`)
+	}
+	wi := azure.WorkloadIdentity
+	if wi == nil || wi.ClientID == "" || wi.TenantID == "" || wi.SubscriptionID == "" || wi.ResourceGroup == "" {
+		return errors.New(`"workloadIdentity.clientID", "workloadIdentity.tenantID", "workloadIdentity.subscriptionID" and "workloadIdentity.resourceGroup" must be specified in "WorkloadIdentity" credentials mode`)
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateGCPWorkloadIdentity() error {
+	gcp := r.Spec.Provider.GCP
+	if gcp == nil {
+		return nil
+	}
+	if gcp.CredentialsMode != GCPCredentialsModeWorkloadIdentityFederation && gcp.WorkloadIdentityFederation != nil {
+		return errors.New(`"workloadIdentityFederation" can only be specified in "WorkloadIdentityFederation" credentials mode`)
+	}
+	if gcp.CredentialsMode != GCPCredentialsModeGKEWorkloadIdentity && gcp.GKEWorkloadIdentity != nil {
+		return errors.New(`"gkeWorkloadIdentity" can only be specified in "GKEWorkloadIdentity" credentials mode`)
+	}
+	switch gcp.CredentialsMode {
+	case GCPCredentialsModeWorkloadIdentityFederation:
+		if gcp.Credentials.Name != "" {
+			return fmt.Errorf(`"credentials" cannot be specified in %q credentials mode`, gcp.CredentialsMode)
+		}
+		wif := gcp.WorkloadIdentityFederation
+		if wif == nil || !gcpWorkloadIdentityPoolProviderRegexp.MatchString(wif.Audience) {
+			return errors.New(`"workloadIdentityFederation.audience" must be the full resource name of the workload identity pool provider in "WorkloadIdentityFederation" credentials mode`)
+		}
+		if wif.ServiceAccountEmail != "" && !gcpServiceAccountEmailRegexp.MatchString(wif.ServiceAccountEmail) {
+			return fmt.Errorf("%q is not a valid GCP service account email", wif.ServiceAccountEmail)
+		}
+	case GCPCredentialsModeGKEWorkloadIdentity:
+		if gcp.Credentials.Name != "" {
+			return fmt.Errorf(`"credentials" cannot be specified in %q credentials mode`, gcp.CredentialsMode)
+		}
+		if gcp.GKEWorkloadIdentity == nil || !gcpServiceAccountEmailRegexp.MatchString(gcp.GKEWorkloadIdentity.ServiceAccountEmail) {
+			return errors.New(`"gkeWorkloadIdentity.serviceAccountEmail" must be a valid GCP service account email in "GKEWorkloadIdentity" credentials mode`)
+		}
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validatePolicy() error {
+	switch r.Spec.Policy {
+	case "", PolicySync, PolicyUpsertOnly, PolicyCreateOnly:
+		return nil
+	}
+	return fmt.Errorf(`"policy" must be one of %q, %q or %q, got %q`, PolicySync, PolicyUpsertOnly, PolicyCreateOnly, r.Spec.Policy)
+}
+
+func (r *ExternalDNS) validateDeletionPolicy() error {
+	// the cleanup job deletes the records for real
+	if r.Spec.DryRun && r.Spec.DeletionPolicy == DeletionPolicyCleanupRecords {
+		return fmt.Errorf(`"deletionPolicy" cannot be %q when "dryRun" is enabled`, DeletionPolicyCleanupRecords)
+	}
+	return nil
+}
+
+func (r *ExternalDNS) validateRegistry() error {
+	registry := r.Spec.Registry
+	if registry.Type == RegistryTypeNoop {
+		if registry.TXT != nil {
+			return errors.New(`"txt" options cannot be specified when registry type is "Noop"`)
+		}
+		// the noop registry considers all the records as owned by the instance
+		if r.Spec.Policy == "" || r.Spec.Policy == PolicySync {
+			return fmt.Errorf(`"policy" must be %q or %q when registry type is "Noop"`, PolicyUpsertOnly, PolicyCreateOnly)
+		}
+		if r.Spec.DeletionPolicy == DeletionPolicyCleanupRecords {
+			return fmt.Errorf(`"deletionPolicy" cannot be %q when registry type is "Noop"`, DeletionPolicyCleanupRecords)
+		}
+		return nil
+	}
+	if registry.TXT == nil {
+		return nil
+	}
+	if registry.TXT.Prefix != "" && registry.TXT.Suffix != "" {
+		return errors.New(`only one of "prefix" and "suffix" can be specified for TXT registry`)
+	}
+	// the AES key is taken from the credentials secret referenced by the provider,
+	// the secret requested from the Cloud Credential Operator doesn't have it
+	if registry.TXT.Encryption != nil && r.providerCredentialsSecretName() == "" {
+		return errors.New(`"encryption" of TXT registry requires the credentials secret of the provider which holds the AES key`)
+	}
+	if registry.TXT.MigrateFromOwnerID != "" {
+		ownerID := r.txtOwnerID()
+		if registry.TXT.MigrateFromOwnerID == ownerID {
+			return fmt.Errorf(`"migrateFromOwnerID" must be different from the owner ID %q`, ownerID)
+		}
+	}
+	return nil
+}
+
+// providerCredentialsSecretName returns the name of the credentials secret referenced by the provider.
+func (r *ExternalDNS) providerCredentialsSecretName() string {
+	provider := r.Spec.Provider
+	switch {
+	case provider.Type == ProviderTypeAWS && provider.AWS != nil:
+		return provider.AWS.Credentials.Name
+	case provider.Type == ProviderTypeAzure && provider.Azure != nil:
+		return provider.Azure.ConfigFile.Name
+	case provider.Type == ProviderTypeGCP && provider.GCP != nil:
+		return provider.GCP.Credentials.Name
+	case provider.Type == ProviderTypeBlueCat && provider.BlueCat != nil:
+		return provider.BlueCat.ConfigFile.Name
+	case provider.Type == ProviderTypeInfoblox && provider.Infoblox != nil:
+		return provider.Infoblox.Credentials.Name
+	case provider.Type == ProviderTypeCloudflare && provider.Cloudflare != nil:
+		return provider.Cloudflare.Credentials.Name
+	case provider.Type == ProviderTypeRFC2136 && provider.RFC2136 != nil:
+		return provider.RFC2136.Credentials.Name
+	case provider.Type == ProviderTypeWebhook && provider.Webhook != nil:
+		return provider.Webhook.Credentials.Name
+	}
+	return ""
+}
+
+func (r *ExternalDNS) validateDeployment() error {
+	deployment := r.Spec.Deployment
+	for key := range deployment.Labels {
+		if key == "app.kubernetes.io/name" || key == "app.kubernetes.io/instance" {
+			return fmt.Errorf(`label %q is reserved for the pod selector and cannot be specified in "deployment.labels"`, key)
+		}
+	}
+	for key := range deployment.Annotations {
+		if strings.HasPrefix(key, GroupVersion.Group+"/") {
+			return fmt.Errorf(`annotation %q is reserved for the operator and cannot be specified in "deployment.annotations"`, key)
+		}
+	}
+	return nil
+}
+
+// overlapWarnings returns a warning for every other ExternalDNS instance
+// which may manage the same DNS records as this one.
+// The overlaps are not rejected: the instances may use different
+// credentials and therefore different accounts of the same provider.
+func (r *ExternalDNS) overlapWarnings() admission.Warnings {
+	if webhookReader == nil {
+		return nil
+	}
+	extDNSList := &ExternalDNSList{}
+	if err := webhookReader.List(context.Background(), extDNSList); err != nil {
+		webhookLog.Error(err, "failed to list ExternalDNS instances, overlaps are not detected", "name", r.Name)
+		return nil
+	}
+	var warnings admission.Warnings
+	for i := range extDNSList.Items {
+		other := &extDNSList.Items[i]
+		if other.Name == r.Name || !r.OverlapsWith(other) {
+			continue
+		}
+		if r.SharesRecordsWith(other) {
+			warnings = append(warnings, fmt.Sprintf("ExternalDNS %q publishes to overlapping zones and domains of the same provider with the same TXT owner ID or the Noop registry, the instances may overwrite or delete each other's DNS records", other.Name))
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("ExternalDNS %q publishes to overlapping zones and domains of the same provider, the instances may compete for the same DNS names", other.Name))
+	}
+	return warnings
+}
+
+// OverlapsWith returns true if the given ExternalDNS instance may manage
+// the same DNS records as this one: both instances publish to the same provider type
+// and share at least one zone and one domain.
+//
+// An empty list of zones overlaps with any zone.
+// The domains are compared by the names of the "Exact" include filters,
+// a name overlaps with itself and its subdomains.
+// An instance without any of them (no domain filters or only patterns)
+// overlaps with any domain as the patterns cannot be compared.
+func (r *ExternalDNS) OverlapsWith(other *ExternalDNS) bool {
+	if r.Spec.Provider.Type != other.Spec.Provider.Type {
+		return false
+	}
+	return zonesOverlap(r.zones(), other.zones()) && domainsOverlap(r.includedDomainNames(), other.includedDomainNames())
+}
+
+// SharesRecordsWith returns true if the given ExternalDNS instance overlaps with this one
+// and any of them may take the DNS records of the other as its own:
+// both instances use the same TXT owner ID, one migrates from the owner ID of the other
+// or any of them uses the Noop registry which owns all the records.
+// The overlapping instances with different owner IDs don't touch each other's records,
+// they only compete for the same DNS names.
+func (r *ExternalDNS) SharesRecordsWith(other *ExternalDNS) bool {
+	if !r.OverlapsWith(other) {
+		return false
+	}
+	if r.Spec.Registry.Type == RegistryTypeNoop || other.Spec.Registry.Type == RegistryTypeNoop {
+		return true
+	}
+	ownerID, otherOwnerID := r.txtOwnerID(), other.txtOwnerID()
+	return ownerID == otherOwnerID || r.txtMigrateFromOwnerID() == otherOwnerID || other.txtMigrateFromOwnerID() == ownerID
+}
+
+// txtOwnerID returns the owner ID stored in the TXT records,
+// the one derived from the name of the instance is used if none is given.
+func (r *ExternalDNS) txtOwnerID() string {
+	if txt := r.Spec.Registry.TXT; txt != nil && txt.OwnerID != "" {
+		return txt.OwnerID
+	}
+	return "external-dns-" + r.Name
+}
+
+// txtMigrateFromOwnerID returns the owner ID of the TXT records which the instance takes over.
+func (r *ExternalDNS) txtMigrateFromOwnerID() string {
+	if txt := r.Spec.Registry.TXT; txt != nil {
+		return txt.MigrateFromOwnerID
+	}
+	return ""
+}
+
+// zones returns the zones the ExternalDNS publishes to.
+// An empty list means all the zones accessible with the credentials.
+func (r *ExternalDNS) zones() []string {
+	if r.Spec.Provider.Type == ProviderTypeRFC2136 && r.Spec.Provider.RFC2136 != nil {
+		// the same zone can be served by different DNS servers
+		return []string{r.Spec.Provider.RFC2136.Host + "/" + normalizeDomainName(r.Spec.Provider.RFC2136.Zone)}
+	}
+	return r.Spec.Zones
+}
+
+// includedDomainNames returns the normalized names of the "Exact" include domain filters.
+// Returns nil if any of the include filters is a pattern.
+func (r *ExternalDNS) includedDomainNames() []string {
+	var names []string
+	for _, d := range r.Spec.Domains {
+		if d.FilterType != FilterTypeInclude {
+			continue
+		}
+		if d.MatchType != DomainMatchTypeExact || d.Name == nil {
+			return nil
+		}
+		names = append(names, normalizeDomainName(*d.Name))
+	}
+	return names
+}
+
+func zonesOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, za := range a {
+		for _, zb := range b {
+			if za == zb {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func domainsOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, da := range a {
+		for _, db := range b {
+			if da == db || strings.HasSuffix(da, "."+db) || strings.HasSuffix(db, "."+da) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func normalizeDomainName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -5,6 +5,8 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -231,6 +233,50 @@ var _ = Describe("ExternalDNS admission webhook", func() {
 			Expect(err).ShouldNot(Succeed())
 			Expect(err.Error()).Should(ContainSubstring(`arn "arn:aws:iam:bad123456789012:role/foo" is not a valid AWS ARN`))
 		})
+		It("accepted with web identity and without credentials", func() {
+			resource := makeExternalDNS("test-aws-web-identity", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeAWS,
+				AWS: &ExternalDNSAWSProviderOptions{
+					CredentialsMode: AWSCredentialsModeWebIdentity,
+					WebIdentity: &ExternalDNSAWSWebIdentityOptions{
+						RoleARN: "arn:aws:iam::123456789012:role/foo",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+		It("rejected with web identity and invalid role ARN", func() {
+			resource := makeExternalDNS("test-aws-web-identity-invalid-arn", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeAWS,
+				AWS: &ExternalDNSAWSProviderOptions{
+					CredentialsMode: AWSCredentialsModeWebIdentity,
+					WebIdentity: &ExternalDNSAWSWebIdentityOptions{
+						RoleARN: "arn:aws:iam:bad123456789012:role/foo",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"webIdentity.roleARN" must be a valid AWS ARN in "WebIdentity" credentials mode`))
+		})
+		It("rejected with web identity options in secret credentials mode", func() {
+			resource := makeExternalDNS("test-aws-web-identity-secret-mode", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeAWS,
+				AWS: &ExternalDNSAWSProviderOptions{
+					Credentials: SecretReference{Name: "credentials"},
+					WebIdentity: &ExternalDNSAWSWebIdentityOptions{
+						RoleARN: "arn:aws:iam::123456789012:role/foo",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"webIdentity" can only be specified in "WebIdentity" credentials mode`))
+		})
 	})
 
 	Context("resource with Azure provider", func() {
@@ -241,6 +287,59 @@ var _ = Describe("ExternalDNS admission webhook", func() {
 			Expect(err).ShouldNot(Succeed())
 			Expect(err.Error()).Should(ContainSubstring("config file name must be specified when provider type is Azure"))
 		})
+		It("accepted with workload identity and without config file", func() {
+			resource := makeExternalDNS("test-azure-workload-identity", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeAzure,
+				Azure: &ExternalDNSAzureProviderOptions{
+					CredentialsMode: AzureCredentialsModeWorkloadIdentity,
+					WorkloadIdentity: &ExternalDNSAzureWorkloadIdentityOptions{
+						ClientID:       "client",
+						TenantID:       "tenant",
+						SubscriptionID: "subscription",
+						ResourceGroup:  "dns",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+		It("rejected with workload identity and config file", func() {
+			resource := makeExternalDNS("test-azure-workload-identity-config-file", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeAzure,
+				Azure: &ExternalDNSAzureProviderOptions{
+					ConfigFile:      SecretReference{Name: "config"},
+					CredentialsMode: AzureCredentialsModeWorkloadIdentity,
+					WorkloadIdentity: &ExternalDNSAzureWorkloadIdentityOptions{
+						ClientID:       "client",
+						TenantID:       "tenant",
+						SubscriptionID: "subscription",
+						ResourceGroup:  "dns",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"configFile" cannot be specified in "WorkloadIdentity" credentials mode`))
+		})
+		It("rejected with workload identity without resource group", func() {
+			resource := makeExternalDNS("test-azure-workload-identity-no-resource-group", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeAzure,
+				Azure: &ExternalDNSAzureProviderOptions{
+					CredentialsMode: AzureCredentialsModeWorkloadIdentity,
+					WorkloadIdentity: &ExternalDNSAzureWorkloadIdentityOptions{
+						ClientID:       "client",
+						TenantID:       "tenant",
+						SubscriptionID: "subscription",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`must be specified in "WorkloadIdentity" credentials mode`))
+		})
 	})
 
 	Context("resource with GCP provider", func() {
@@ -251,6 +350,99 @@ var _ = Describe("ExternalDNS admission webhook", func() {
 			Expect(err).ShouldNot(Succeed())
 			Expect(err.Error()).Should(ContainSubstring("credentials secret must be specified when provider type is GCP"))
 		})
+		It("accepted with workload identity federation and without credentials", func() {
+			resource := makeExternalDNS("test-gcp-workload-identity-federation", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeGCP,
+				GCP: &ExternalDNSGCPProviderOptions{
+					CredentialsMode: GCPCredentialsModeWorkloadIdentityFederation,
+					WorkloadIdentityFederation: &ExternalDNSGCPWorkloadIdentityFederationOptions{
+						Audience:            "//iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/pool/providers/provider",
+						ServiceAccountEmail: "external-dns@my-project.iam.gserviceaccount.com",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+		It("rejected with workload identity federation and invalid audience", func() {
+			resource := makeExternalDNS("test-gcp-workload-identity-federation-audience", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeGCP,
+				GCP: &ExternalDNSGCPProviderOptions{
+					CredentialsMode: GCPCredentialsModeWorkloadIdentityFederation,
+					WorkloadIdentityFederation: &ExternalDNSGCPWorkloadIdentityFederationOptions{
+						Audience: "sts.googleapis.com",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"workloadIdentityFederation.audience" must be the full resource name of the workload identity pool provider`))
+		})
+		It("rejected with workload identity federation and credentials", func() {
+			resource := makeExternalDNS("test-gcp-workload-identity-federation-credentials", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeGCP,
+				GCP: &ExternalDNSGCPProviderOptions{
+					Credentials:     SecretReference{Name: "credentials"},
+					CredentialsMode: GCPCredentialsModeWorkloadIdentityFederation,
+					WorkloadIdentityFederation: &ExternalDNSGCPWorkloadIdentityFederationOptions{
+						Audience: "//iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/pool/providers/provider",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"credentials" cannot be specified in "WorkloadIdentityFederation" credentials mode`))
+		})
+		It("accepted with GKE workload identity and without credentials", func() {
+			resource := makeExternalDNS("test-gcp-gke-workload-identity", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeGCP,
+				GCP: &ExternalDNSGCPProviderOptions{
+					CredentialsMode: GCPCredentialsModeGKEWorkloadIdentity,
+					GKEWorkloadIdentity: &ExternalDNSGCPGKEWorkloadIdentityOptions{
+						ServiceAccountEmail: "external-dns@my-project.iam.gserviceaccount.com",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+		It("rejected with GKE workload identity and invalid service account email", func() {
+			resource := makeExternalDNS("test-gcp-gke-workload-identity-email", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeGCP,
+				GCP: &ExternalDNSGCPProviderOptions{
+					CredentialsMode: GCPCredentialsModeGKEWorkloadIdentity,
+					GKEWorkloadIdentity: &ExternalDNSGCPGKEWorkloadIdentityOptions{
+						ServiceAccountEmail: "external-dns@example.com",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"gkeWorkloadIdentity.serviceAccountEmail" must be a valid GCP service account email`))
+		})
+		It("rejected with GKE workload identity options in workload identity federation mode", func() {
+			resource := makeExternalDNS("test-gcp-gke-workload-identity-wrong-mode", nil)
+			resource.Spec.Provider = ExternalDNSProvider{
+				Type: ProviderTypeGCP,
+				GCP: &ExternalDNSGCPProviderOptions{
+					CredentialsMode: GCPCredentialsModeWorkloadIdentityFederation,
+					WorkloadIdentityFederation: &ExternalDNSGCPWorkloadIdentityFederationOptions{
+						Audience: "//iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/pool/providers/provider",
+					},
+					GKEWorkloadIdentity: &ExternalDNSGCPGKEWorkloadIdentityOptions{
+						ServiceAccountEmail: "external-dns@my-project.iam.gserviceaccount.com",
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"gkeWorkloadIdentity" can only be specified in "GKEWorkloadIdentity" credentials mode`))
+		})
 	})
 
 	Context("resource with Bluecat provider", func() {
@@ -313,6 +505,300 @@ var _ = Describe("ExternalDNS admission webhook", func() {
 		})
 	})
 
+	Context("resource with Cloudflare provider", func() {
+		It("rejected when provider Cloudflare credentials are not specified", func() {
+			resource := makeExternalDNS("test-missing-cloudflare-credentials", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeCloudflare, Cloudflare: &ExternalDNSCloudflareProviderOptions{
+				Proxied: true,
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("credentials secret must be specified when provider type is Cloudflare"))
+		})
+
+		It("accepted when provider Cloudflare credentials are specified", func() {
+			resource := makeExternalDNS("test-cloudflare", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeCloudflare, Cloudflare: &ExternalDNSCloudflareProviderOptions{
+				Credentials: SecretReference{Name: "cloudflare-credentials"},
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+	})
+
+	Context("resource with RFC2136 provider", func() {
+		It("rejected when provider RFC2136 options are not specified", func() {
+			resource := makeExternalDNS("test-missing-rfc2136-options", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeRFC2136}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"host", "zone" and credentials secret must be specified when provider type is RFC2136`))
+		})
+
+		It("rejected when TSIG key name is not specified without GSS-TSIG", func() {
+			resource := makeExternalDNS("test-missing-rfc2136-tsig-keyname", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeRFC2136, RFC2136: &ExternalDNSRFC2136ProviderOptions{
+				Credentials: SecretReference{Name: "rfc2136-credentials"},
+				Host:        "dns.example.com",
+				Zone:        "example.com",
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"tsigKeyName" must be specified when GSS-TSIG is not enabled for RFC2136 provider`))
+		})
+
+		It("rejected when zones are specified", func() {
+			resource := makeExternalDNS("test-rfc2136-zones", nil)
+			resource.Spec.Zones = []string{"example.com"}
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeRFC2136, RFC2136: &ExternalDNSRFC2136ProviderOptions{
+				Credentials: SecretReference{Name: "rfc2136-credentials"},
+				Host:        "dns.example.com",
+				Zone:        "example.com",
+				TSIGKeyName: "externaldns-key",
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"zones" cannot be specified when provider type is RFC2136, use "rfc2136.zone" instead`))
+		})
+
+		It("accepted when TSIG is used", func() {
+			resource := makeExternalDNS("test-rfc2136-tsig", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeRFC2136, RFC2136: &ExternalDNSRFC2136ProviderOptions{
+				Credentials:         SecretReference{Name: "rfc2136-credentials"},
+				Host:                "dns.example.com",
+				Zone:                "example.com",
+				TSIGKeyName:         "externaldns-key",
+				TSIGSecretAlgorithm: "hmac-sha256",
+				TSIGAXFR:            true,
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("accepted when GSS-TSIG is used", func() {
+			resource := makeExternalDNS("test-rfc2136-gss-tsig", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeRFC2136, RFC2136: &ExternalDNSRFC2136ProviderOptions{
+				Credentials: SecretReference{Name: "rfc2136-credentials"},
+				Host:        "dns.example.com",
+				Zone:        "example.com",
+				GSSTSIG:     &ExternalDNSRFC2136GSSTSIGOptions{KerberosRealm: "EXAMPLE.COM"},
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+	})
+
+	Context("resource with Webhook provider", func() {
+		It("rejected when provider Webhook options are not specified", func() {
+			resource := makeExternalDNS("test-missing-webhook-options", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeWebhook}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"image" and credentials secret must be specified when provider type is Webhook`))
+		})
+
+		It("rejected when env variable has both value and secret key", func() {
+			resource := makeExternalDNS("test-webhook-env-value-and-secret-key", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeWebhook, Webhook: &ExternalDNSWebhookProviderOptions{
+				Credentials: SecretReference{Name: "webhook-credentials"},
+				Image:       "quay.io/test/external-dns-webhook:latest",
+				Env: []ExternalDNSWebhookProviderEnvVar{
+					{Name: "API_TOKEN", Value: "token", SecretKey: "token"},
+				},
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`exactly one of "value" or "secretKey" must be specified for webhook provider env variable "API_TOKEN"`))
+		})
+
+		It("rejected when zones are specified", func() {
+			resource := makeExternalDNS("test-webhook-zones", nil)
+			resource.Spec.Zones = []string{"example.com"}
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeWebhook, Webhook: &ExternalDNSWebhookProviderOptions{
+				Credentials: SecretReference{Name: "webhook-credentials"},
+				Image:       "quay.io/test/external-dns-webhook:latest",
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"zones" cannot be specified when provider type is Webhook`))
+		})
+
+		It("accepted when provider Webhook options are specified", func() {
+			resource := makeExternalDNS("test-webhook", nil)
+			resource.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeWebhook, Webhook: &ExternalDNSWebhookProviderOptions{
+				Credentials: SecretReference{Name: "webhook-credentials"},
+				Image:       "quay.io/test/external-dns-webhook:latest",
+				Args:        []string{"--log-level=debug"},
+				Env: []ExternalDNSWebhookProviderEnvVar{
+					{Name: "WEBHOOK_PORT", Value: "8888"},
+					{Name: "API_TOKEN", SecretKey: "token"},
+				},
+				Port: 8888,
+			}}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+	})
+
+	Context("resource with operand namespace", func() {
+		It("rejected when operand namespace is changed", func() {
+			resource := makeExternalDNS("test-operand-namespace-change", nil)
+			resource.Spec.OperandNamespace = "tenant-a"
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+
+			resource.Spec.OperandNamespace = "tenant-b"
+			err = k8sClient.Update(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"operandNamespace" cannot be changed after creation`))
+		})
+
+		It("rejected when operand namespace is not allowed", func() {
+			resource := makeExternalDNS("test-operand-namespace-not-allowed", nil)
+			resource.Spec.OperandNamespace = "tenant-c"
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`operand namespace "tenant-c" is not allowed`))
+		})
+
+		It("accepted when operand namespace is unchanged", func() {
+			resource := makeExternalDNS("test-operand-namespace-unchanged", nil)
+			resource.Spec.OperandNamespace = "tenant-a"
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+
+			resource.Spec.Zones = []string{"my-dns-public-zone"}
+			err = k8sClient.Update(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+	})
+
+	Context("resource with policy", func() {
+		It("accepted with UpsertOnly policy", func() {
+			resource := makeExternalDNS("test-policy-upsert-only", nil)
+			resource.Spec.Policy = PolicyUpsertOnly
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("rejected with unknown policy", func() {
+			resource := makeExternalDNS("test-policy-unknown", nil)
+			resource.Spec.Policy = "DeleteOnly"
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("policy"))
+		})
+	})
+
+	Context("resource with dry run", func() {
+		It("accepted with Retain deletion policy", func() {
+			resource := makeExternalDNS("test-dry-run-retain", nil)
+			resource.Spec.DryRun = true
+			resource.Spec.DeletionPolicy = DeletionPolicyRetain
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("rejected with CleanupRecords deletion policy", func() {
+			resource := makeExternalDNS("test-dry-run-cleanup", nil)
+			resource.Spec.DryRun = true
+			resource.Spec.DeletionPolicy = DeletionPolicyCleanupRecords
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"deletionPolicy" cannot be "CleanupRecords" when "dryRun" is enabled`))
+		})
+
+		It("rejected when enabled on an instance with CleanupRecords deletion policy", func() {
+			resource := makeExternalDNS("test-dry-run-cleanup-update", nil)
+			resource.Spec.DeletionPolicy = DeletionPolicyCleanupRecords
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+
+			resource.Spec.DryRun = true
+			err = k8sClient.Update(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"deletionPolicy"`))
+		})
+	})
+
+	Context("resource with registry", func() {
+		It("accepted with TXT registry options", func() {
+			resource := makeExternalDNS("test-registry-txt", nil)
+			resource.Spec.Registry = ExternalDNSRegistry{
+				Type: RegistryTypeTXT,
+				TXT: &ExternalDNSTXTRegistryOptions{
+					OwnerID:    "legacy-owner",
+					Suffix:     "-txt",
+					Encryption: &ExternalDNSTXTRegistryEncryption{AESKeySecretKey: "aes-key"},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("rejected with encryption and no credentials secret", func() {
+			resource := makeExternalDNS("test-registry-encryption-no-secret", nil)
+			resource.Spec.Provider.AWS = &ExternalDNSAWSProviderOptions{
+				CredentialsMode: AWSCredentialsModeWebIdentity,
+				WebIdentity:     &ExternalDNSAWSWebIdentityOptions{RoleARN: "arn:aws:iam::123456789012:role/external-dns"},
+			}
+			resource.Spec.Registry.TXT = &ExternalDNSTXTRegistryOptions{
+				Encryption: &ExternalDNSTXTRegistryEncryption{AESKeySecretKey: "aes-key"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"encryption"`))
+		})
+
+		It("rejected when both prefix and suffix are specified", func() {
+			resource := makeExternalDNS("test-registry-prefix-suffix", nil)
+			resource.Spec.Registry.TXT = &ExternalDNSTXTRegistryOptions{Prefix: "txt-", Suffix: "-txt"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"prefix" and "suffix"`))
+		})
+
+		It("accepted with owner migration", func() {
+			resource := makeExternalDNS("test-registry-migration", nil)
+			resource.Spec.Registry.TXT = &ExternalDNSTXTRegistryOptions{MigrateFromOwnerID: "external-dns-old-name"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("rejected when migrating from the current owner", func() {
+			resource := makeExternalDNS("test-registry-migration-same", nil)
+			resource.Spec.Registry.TXT = &ExternalDNSTXTRegistryOptions{MigrateFromOwnerID: "external-dns-test-registry-migration-same"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"migrateFromOwnerID"`))
+		})
+
+		It("accepted with Noop registry and UpsertOnly policy", func() {
+			resource := makeExternalDNS("test-registry-noop", nil)
+			resource.Spec.Registry.Type = RegistryTypeNoop
+			resource.Spec.Policy = PolicyUpsertOnly
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("rejected with Noop registry and Sync policy", func() {
+			resource := makeExternalDNS("test-registry-noop-sync", nil)
+			resource.Spec.Registry.Type = RegistryTypeNoop
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"policy"`))
+		})
+
+		It("rejected with Noop registry and TXT options", func() {
+			resource := makeExternalDNS("test-registry-noop-txt", nil)
+			resource.Spec.Registry = ExternalDNSRegistry{Type: RegistryTypeNoop, TXT: &ExternalDNSTXTRegistryOptions{OwnerID: "owner"}}
+			resource.Spec.Policy = PolicyCreateOnly
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"txt" options`))
+		})
+	})
+
 	Context("resource with multiple missing fields", func() {
 		It("should be rejected with all errors", func() {
 			resource := makeExternalDNS(
@@ -335,12 +821,277 @@ var _ = Describe("ExternalDNS admission webhook", func() {
 	})
 
 	Context("resource with crd source", func() {
-		It("should be rejected as not implemented", func() {
+		It("should be accepted without source options", func() {
 			resource := makeExternalDNS("test-crd-source", nil)
 			resource.Spec.Source.Type = SourceTypeCRD
 			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("should be accepted with source options", func() {
+			resource := makeExternalDNS("test-crd-source-options", nil)
+			resource.Spec.Source.Type = SourceTypeCRD
+			resource.Spec.Source.CRD = &ExternalDNSCRDSourceOptions{
+				Kind:        "DNSEndpoint",
+				Version:     "externaldns.k8s.io/v1alpha1",
+				LabelFilter: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("should be rejected when both label filters are specified", func() {
+			resource := makeExternalDNS("test-crd-source-label-filters", nil)
+			resource.Spec.Source.Type = SourceTypeCRD
+			resource.Spec.Source.LabelFilter = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+			resource.Spec.Source.CRD = &ExternalDNSCRDSourceOptions{
+				Kind:        "DNSEndpoint",
+				Version:     "externaldns.k8s.io/v1alpha1",
+				LabelFilter: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			}
+			err := k8sClient.Create(context.Background(), resource)
 			Expect(err).ShouldNot(Succeed())
-			Expect(err.Error()).Should(ContainSubstring("CRD source is not implemented"))
+			Expect(err.Error()).Should(ContainSubstring(`only one of "labelFilter" and "crd.labelFilter" can be specified`))
+		})
+	})
+
+	Context("resource with ingress source", func() {
+		It("should be accepted without fqdnTemplate", func() {
+			resource := makeExternalDNS("test-ingress-source", nil)
+			resource.Spec.Source.Type = SourceTypeIngress
+			resource.Spec.Source.FQDNTemplate = nil
+			resource.Spec.Source.Ingress = &ExternalDNSIngressSourceOptions{
+				IngressClassNames: []string{"nginx"},
+				IgnoreTLSSpec:     true,
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("should be rejected when ingress options are given for other source type", func() {
+			resource := makeExternalDNS("test-ingress-options-service", nil)
+			resource.Spec.Source.Ingress = &ExternalDNSIngressSourceOptions{
+				IngressClassNames: []string{"nginx"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"ingress" options cannot be specified for "Service" source type`))
+		})
+	})
+
+	Context("resource with additional sources", func() {
+		It("should be accepted", func() {
+			resource := makeExternalDNS("test-additional-sources", nil)
+			resource.Spec.AdditionalSources = []ExternalDNSSourceUnion{
+				{
+					Type:           SourceTypeRoute,
+					OpenShiftRoute: &ExternalDNSOpenShiftRouteOptions{RouterName: "default"},
+				},
+				{
+					Type:        SourceTypeGatewayHTTPRoute,
+					LabelFilter: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("should be rejected when a source type is duplicated", func() {
+			resource := makeExternalDNS("test-additional-sources-duplicate", nil)
+			resource.Spec.AdditionalSources = []ExternalDNSSourceUnion{
+				{Type: SourceTypeRoute},
+				{Type: SourceTypeService},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`source type "Service" can be specified only once`))
+		})
+
+		It("should be rejected when many label filters are specified", func() {
+			resource := makeExternalDNS("test-additional-sources-label-filters", nil)
+			resource.Spec.Source.LabelFilter = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+			resource.Spec.AdditionalSources = []ExternalDNSSourceUnion{
+				{
+					Type: SourceTypeCRD,
+					CRD: &ExternalDNSCRDSourceOptions{
+						Kind:        "DNSEndpoint",
+						Version:     "externaldns.k8s.io/v1alpha1",
+						LabelFilter: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+					},
+				},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring("only one label filter can be specified across all the sources"))
+		})
+
+		It("should be rejected when fqdnTemplate is missing for additional service source", func() {
+			resource := makeExternalDNS("test-additional-sources-service", nil)
+			resource.Spec.Source.Type = SourceTypeRoute
+			resource.Spec.Source.FQDNTemplate = nil
+			resource.Spec.AdditionalSources = []ExternalDNSSourceUnion{
+				{Type: SourceTypeService},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"fqdnTemplate" must be specified when "hostnameAnnotation" is "Ignore"`))
+		})
+	})
+
+	Context("resource with gateway source", func() {
+		It("should be accepted without source options", func() {
+			resource := makeExternalDNS("test-gateway-source", nil)
+			resource.Spec.Source.Type = SourceTypeGatewayHTTPRoute
+			resource.Spec.Source.FQDNTemplate = nil
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("should be accepted with source options", func() {
+			resource := makeExternalDNS("test-gateway-source-options", nil)
+			resource.Spec.Source.Type = SourceTypeGatewayTLSRoute
+			resource.Spec.Source.Gateway = &ExternalDNSGatewayRouteSourceOptions{
+				GatewayNamespace:   "openshift-ingress",
+				GatewayLabelFilter: &metav1.LabelSelector{MatchLabels: map[string]string{"gateway": "public"}},
+				RouteLabelFilter:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("should be rejected when both label filters are specified", func() {
+			resource := makeExternalDNS("test-gateway-source-label-filters", nil)
+			resource.Spec.Source.Type = SourceTypeGatewayHTTPRoute
+			resource.Spec.Source.LabelFilter = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+			resource.Spec.Source.Gateway = &ExternalDNSGatewayRouteSourceOptions{
+				RouteLabelFilter: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`only one of "labelFilter" and "gateway.routeLabelFilter" can be specified`))
+		})
+
+		It("should be rejected when gateway options are given for other source type", func() {
+			resource := makeExternalDNS("test-gateway-options-service", nil)
+			resource.Spec.Source.Gateway = &ExternalDNSGatewayRouteSourceOptions{
+				GatewayNamespace: "openshift-ingress",
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`"gateway" options cannot be specified for "Service" source type`))
+		})
+	})
+
+	Context("resource with deployment overrides", func() {
+		It("should be accepted with scheduling and resource overrides", func() {
+			resource := makeExternalDNS("test-deployment-overrides", nil)
+			resource.Spec.Deployment = ExternalDNSDeploymentOptions{
+				Resources: ExternalDNSContainerResources{
+					ExternalDNS: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: k8sresource.MustParse("10m")},
+					},
+				},
+				NodeSelector:      map[string]string{"node-role.kubernetes.io/infra": ""},
+				PriorityClassName: "system-cluster-critical",
+				Labels:            map[string]string{"team": "dns"},
+				Annotations:       map[string]string{"example.com/owner": "dns-team"},
+			}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).Should(Succeed())
+		})
+
+		It("should be rejected when a selector label is specified", func() {
+			resource := makeExternalDNS("test-deployment-selector-label", nil)
+			resource.Spec.Deployment.Labels = map[string]string{"app.kubernetes.io/instance": "other"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`label "app.kubernetes.io/instance" is reserved for the pod selector`))
+		})
+
+		It("should be rejected when an operator annotation is specified", func() {
+			resource := makeExternalDNS("test-deployment-operator-annotation", nil)
+			resource.Spec.Deployment.Annotations = map[string]string{"externaldns.olm.openshift.io/credentials-secret-hash": "abc"}
+			err := k8sClient.Create(context.Background(), resource)
+			Expect(err).ShouldNot(Succeed())
+			Expect(err.Error()).Should(ContainSubstring(`annotation "externaldns.olm.openshift.io/credentials-secret-hash" is reserved for the operator`))
+		})
+	})
+
+	Context("overlapping resources", func() {
+		It("should be accepted with a warning when zones and domains overlap", func() {
+			existing := makeExternalDNS("test-overlap-existing", []ExternalDNSDomain{
+				{
+					FilterType:             FilterTypeInclude,
+					ExternalDNSDomainUnion: ExternalDNSDomainUnion{MatchType: DomainMatchTypeExact, Name: ptr.To("example.com")},
+				},
+			})
+			existing.Spec.Zones = []string{"overlapping-zone"}
+			Expect(k8sClient.Create(context.Background(), existing)).Should(Succeed())
+
+			resource := makeExternalDNS("test-overlap-new", []ExternalDNSDomain{
+				{
+					FilterType:             FilterTypeInclude,
+					ExternalDNSDomainUnion: ExternalDNSDomainUnion{MatchType: DomainMatchTypeExact, Name: ptr.To("apps.example.com")},
+				},
+			})
+			resource.Spec.Zones = []string{"overlapping-zone"}
+			warnings, err := resource.ValidateCreate()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(warnings).Should(ContainElement(ContainSubstring(`ExternalDNS "test-overlap-existing" publishes to overlapping zones and domains`)))
+		})
+
+		It("should detect the overlaps of zones and domains", func() {
+			withDomain := func(name string, zones []string, domain string) *ExternalDNS {
+				resource := makeExternalDNS(name, []ExternalDNSDomain{
+					{
+						FilterType:             FilterTypeInclude,
+						ExternalDNSDomainUnion: ExternalDNSDomainUnion{MatchType: DomainMatchTypeExact, Name: ptr.To(domain)},
+					},
+				})
+				resource.Spec.Zones = zones
+				return resource
+			}
+			a := withDomain("a", []string{"zone-1"}, "foo.example.com")
+			Expect(a.OverlapsWith(withDomain("b", []string{"zone-1", "zone-2"}, "Foo.Example.com."))).Should(BeTrue())
+			Expect(a.OverlapsWith(withDomain("b", nil, "example.com"))).Should(BeTrue())
+			Expect(a.OverlapsWith(makeExternalDNS("b", nil))).Should(BeTrue())
+			Expect(a.OverlapsWith(withDomain("b", []string{"zone-2"}, "foo.example.com"))).Should(BeFalse())
+			Expect(a.OverlapsWith(withDomain("b", []string{"zone-1"}, "barfoo.example.com"))).Should(BeFalse())
+
+			other := withDomain("b", []string{"zone-1"}, "foo.example.com")
+			other.Spec.Provider = ExternalDNSProvider{Type: ProviderTypeGCP}
+			Expect(a.OverlapsWith(other)).Should(BeFalse())
+		})
+
+		It("should detect the overlaps which share the DNS records", func() {
+			withRegistry := func(name string, zones []string, registry ExternalDNSRegistry) *ExternalDNS {
+				resource := makeExternalDNS(name, nil)
+				resource.Spec.Zones = zones
+				resource.Spec.Registry = registry
+				return resource
+			}
+			sharedOwner := ExternalDNSRegistry{TXT: &ExternalDNSTXTRegistryOptions{OwnerID: "shared-owner"}}
+			a := withRegistry("a", []string{"zone-1"}, sharedOwner)
+			Expect(a.SharesRecordsWith(withRegistry("b", []string{"zone-1"}, sharedOwner))).Should(BeTrue())
+			Expect(a.SharesRecordsWith(withRegistry("b", []string{"zone-1"}, ExternalDNSRegistry{Type: RegistryTypeNoop}))).Should(BeTrue())
+			Expect(a.SharesRecordsWith(withRegistry("b", []string{"zone-1"}, ExternalDNSRegistry{TXT: &ExternalDNSTXTRegistryOptions{MigrateFromOwnerID: "shared-owner"}}))).Should(BeTrue())
+			Expect(a.SharesRecordsWith(withRegistry("shared-owner", []string{"zone-1"}, ExternalDNSRegistry{}))).Should(BeFalse())
+			Expect(a.SharesRecordsWith(withRegistry("b", []string{"zone-1"}, ExternalDNSRegistry{}))).Should(BeFalse())
+			Expect(a.SharesRecordsWith(withRegistry("b", []string{"zone-2"}, sharedOwner))).Should(BeFalse())
+		})
+
+		It("should be accepted with a warning about the shared records when the TXT owner ID is the same", func() {
+			existing := makeExternalDNS("test-shared-owner-existing", nil)
+			existing.Spec.Zones = []string{"shared-owner-zone"}
+			existing.Spec.Registry.TXT = &ExternalDNSTXTRegistryOptions{OwnerID: "shared-owner"}
+			Expect(k8sClient.Create(context.Background(), existing)).Should(Succeed())
+
+			resource := makeExternalDNS("test-shared-owner-new", nil)
+			resource.Spec.Zones = []string{"shared-owner-zone"}
+			resource.Spec.Registry.TXT = &ExternalDNSTXTRegistryOptions{OwnerID: "shared-owner"}
+			warnings, err := resource.ValidateCreate()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(warnings).Should(ContainElement(ContainSubstring(`ExternalDNS "test-shared-owner-existing" publishes to overlapping zones and domains of the same provider with the same TXT owner ID`)))
 		})
 	})
 })
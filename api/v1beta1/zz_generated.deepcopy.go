@@ -21,8 +21,8 @@ limitations under the License.
 package v1beta1
 
 import (
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -77,6 +77,11 @@ func (in *ExternalDNSAWSProviderOptions) DeepCopyInto(out *ExternalDNSAWSProvide
 		*out = new(ExternalDNSAWSAssumeRoleOptions)
 		**out = **in
 	}
+	if in.WebIdentity != nil {
+		in, out := &in.WebIdentity, &out.WebIdentity
+		*out = new(ExternalDNSAWSWebIdentityOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSAWSProviderOptions.
@@ -89,10 +94,30 @@ func (in *ExternalDNSAWSProviderOptions) DeepCopy() *ExternalDNSAWSProviderOptio
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSAWSWebIdentityOptions) DeepCopyInto(out *ExternalDNSAWSWebIdentityOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSAWSWebIdentityOptions.
+func (in *ExternalDNSAWSWebIdentityOptions) DeepCopy() *ExternalDNSAWSWebIdentityOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSAWSWebIdentityOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSAzureProviderOptions) DeepCopyInto(out *ExternalDNSAzureProviderOptions) {
 	*out = *in
 	out.ConfigFile = in.ConfigFile
+	if in.WorkloadIdentity != nil {
+		in, out := &in.WorkloadIdentity, &out.WorkloadIdentity
+		*out = new(ExternalDNSAzureWorkloadIdentityOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSAzureProviderOptions.
@@ -105,6 +130,21 @@ func (in *ExternalDNSAzureProviderOptions) DeepCopy() *ExternalDNSAzureProviderO
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSAzureWorkloadIdentityOptions) DeepCopyInto(out *ExternalDNSAzureWorkloadIdentityOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSAzureWorkloadIdentityOptions.
+func (in *ExternalDNSAzureWorkloadIdentityOptions) DeepCopy() *ExternalDNSAzureWorkloadIdentityOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSAzureWorkloadIdentityOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSBlueCatProviderOptions) DeepCopyInto(out *ExternalDNSBlueCatProviderOptions) {
 	*out = *in
@@ -126,7 +166,7 @@ func (in *ExternalDNSCRDSourceOptions) DeepCopyInto(out *ExternalDNSCRDSourceOpt
 	*out = *in
 	if in.LabelFilter != nil {
 		in, out := &in.LabelFilter, &out.LabelFilter
-		*out = new(v1.LabelSelector)
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -141,6 +181,108 @@ func (in *ExternalDNSCRDSourceOptions) DeepCopy() *ExternalDNSCRDSourceOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSCloudflareProviderOptions) DeepCopyInto(out *ExternalDNSCloudflareProviderOptions) {
+	*out = *in
+	out.Credentials = in.Credentials
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSCloudflareProviderOptions.
+func (in *ExternalDNSCloudflareProviderOptions) DeepCopy() *ExternalDNSCloudflareProviderOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSCloudflareProviderOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSContainerResources) DeepCopyInto(out *ExternalDNSContainerResources) {
+	*out = *in
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricsProxy != nil {
+		in, out := &in.MetricsProxy, &out.MetricsProxy
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WebhookProvider != nil {
+		in, out := &in.WebhookProvider, &out.WebhookProvider
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSContainerResources.
+func (in *ExternalDNSContainerResources) DeepCopy() *ExternalDNSContainerResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSContainerResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSDeploymentOptions) DeepCopyInto(out *ExternalDNSDeploymentOptions) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSDeploymentOptions.
+func (in *ExternalDNSDeploymentOptions) DeepCopy() *ExternalDNSDeploymentOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSDeploymentOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSDomain) DeepCopyInto(out *ExternalDNSDomain) {
 	*out = *in
@@ -182,6 +324,21 @@ func (in *ExternalDNSDomainUnion) DeepCopy() *ExternalDNSDomainUnion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSGCPGKEWorkloadIdentityOptions) DeepCopyInto(out *ExternalDNSGCPGKEWorkloadIdentityOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSGCPGKEWorkloadIdentityOptions.
+func (in *ExternalDNSGCPGKEWorkloadIdentityOptions) DeepCopy() *ExternalDNSGCPGKEWorkloadIdentityOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSGCPGKEWorkloadIdentityOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSGCPProviderOptions) DeepCopyInto(out *ExternalDNSGCPProviderOptions) {
 	*out = *in
@@ -191,6 +348,16 @@ func (in *ExternalDNSGCPProviderOptions) DeepCopyInto(out *ExternalDNSGCPProvide
 		**out = **in
 	}
 	out.Credentials = in.Credentials
+	if in.WorkloadIdentityFederation != nil {
+		in, out := &in.WorkloadIdentityFederation, &out.WorkloadIdentityFederation
+		*out = new(ExternalDNSGCPWorkloadIdentityFederationOptions)
+		**out = **in
+	}
+	if in.GKEWorkloadIdentity != nil {
+		in, out := &in.GKEWorkloadIdentity, &out.GKEWorkloadIdentity
+		*out = new(ExternalDNSGCPGKEWorkloadIdentityOptions)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSGCPProviderOptions.
@@ -203,6 +370,46 @@ func (in *ExternalDNSGCPProviderOptions) DeepCopy() *ExternalDNSGCPProviderOptio
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSGCPWorkloadIdentityFederationOptions) DeepCopyInto(out *ExternalDNSGCPWorkloadIdentityFederationOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSGCPWorkloadIdentityFederationOptions.
+func (in *ExternalDNSGCPWorkloadIdentityFederationOptions) DeepCopy() *ExternalDNSGCPWorkloadIdentityFederationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSGCPWorkloadIdentityFederationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSGatewayRouteSourceOptions) DeepCopyInto(out *ExternalDNSGatewayRouteSourceOptions) {
+	*out = *in
+	if in.GatewayLabelFilter != nil {
+		in, out := &in.GatewayLabelFilter, &out.GatewayLabelFilter
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteLabelFilter != nil {
+		in, out := &in.RouteLabelFilter, &out.RouteLabelFilter
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSGatewayRouteSourceOptions.
+func (in *ExternalDNSGatewayRouteSourceOptions) DeepCopy() *ExternalDNSGatewayRouteSourceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSGatewayRouteSourceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSInfobloxProviderOptions) DeepCopyInto(out *ExternalDNSInfobloxProviderOptions) {
 	*out = *in
@@ -219,6 +426,26 @@ func (in *ExternalDNSInfobloxProviderOptions) DeepCopy() *ExternalDNSInfobloxPro
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSIngressSourceOptions) DeepCopyInto(out *ExternalDNSIngressSourceOptions) {
+	*out = *in
+	if in.IngressClassNames != nil {
+		in, out := &in.IngressClassNames, &out.IngressClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSIngressSourceOptions.
+func (in *ExternalDNSIngressSourceOptions) DeepCopy() *ExternalDNSIngressSourceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSIngressSourceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSList) DeepCopyInto(out *ExternalDNSList) {
 	*out = *in
@@ -251,6 +478,36 @@ func (in *ExternalDNSList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSLogging) DeepCopyInto(out *ExternalDNSLogging) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSLogging.
+func (in *ExternalDNSLogging) DeepCopy() *ExternalDNSLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSMetrics) DeepCopyInto(out *ExternalDNSMetrics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSMetrics.
+func (in *ExternalDNSMetrics) DeepCopy() *ExternalDNSMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSOpenShiftRouteOptions) DeepCopyInto(out *ExternalDNSOpenShiftRouteOptions) {
 	*out = *in
@@ -282,7 +539,7 @@ func (in *ExternalDNSProvider) DeepCopyInto(out *ExternalDNSProvider) {
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(ExternalDNSAzureProviderOptions)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.BlueCat != nil {
 		in, out := &in.BlueCat, &out.BlueCat
@@ -294,6 +551,21 @@ func (in *ExternalDNSProvider) DeepCopyInto(out *ExternalDNSProvider) {
 		*out = new(ExternalDNSInfobloxProviderOptions)
 		**out = **in
 	}
+	if in.Cloudflare != nil {
+		in, out := &in.Cloudflare, &out.Cloudflare
+		*out = new(ExternalDNSCloudflareProviderOptions)
+		**out = **in
+	}
+	if in.RFC2136 != nil {
+		in, out := &in.RFC2136, &out.RFC2136
+		*out = new(ExternalDNSRFC2136ProviderOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(ExternalDNSWebhookProviderOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSProvider.
@@ -306,12 +578,68 @@ func (in *ExternalDNSProvider) DeepCopy() *ExternalDNSProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSRFC2136GSSTSIGOptions) DeepCopyInto(out *ExternalDNSRFC2136GSSTSIGOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSRFC2136GSSTSIGOptions.
+func (in *ExternalDNSRFC2136GSSTSIGOptions) DeepCopy() *ExternalDNSRFC2136GSSTSIGOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSRFC2136GSSTSIGOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSRFC2136ProviderOptions) DeepCopyInto(out *ExternalDNSRFC2136ProviderOptions) {
+	*out = *in
+	out.Credentials = in.Credentials
+	if in.GSSTSIG != nil {
+		in, out := &in.GSSTSIG, &out.GSSTSIG
+		*out = new(ExternalDNSRFC2136GSSTSIGOptions)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSRFC2136ProviderOptions.
+func (in *ExternalDNSRFC2136ProviderOptions) DeepCopy() *ExternalDNSRFC2136ProviderOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSRFC2136ProviderOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSRegistry) DeepCopyInto(out *ExternalDNSRegistry) {
+	*out = *in
+	if in.TXT != nil {
+		in, out := &in.TXT, &out.TXT
+		*out = new(ExternalDNSTXTRegistryOptions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSRegistry.
+func (in *ExternalDNSRegistry) DeepCopy() *ExternalDNSRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDNSServiceSourceOptions) DeepCopyInto(out *ExternalDNSServiceSourceOptions) {
 	*out = *in
 	if in.ServiceType != nil {
 		in, out := &in.ServiceType, &out.ServiceType
-		*out = make([]corev1.ServiceType, len(*in))
+		*out = make([]v1.ServiceType, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -352,7 +680,7 @@ func (in *ExternalDNSSourceUnion) DeepCopyInto(out *ExternalDNSSourceUnion) {
 	*out = *in
 	if in.LabelFilter != nil {
 		in, out := &in.LabelFilter, &out.LabelFilter
-		*out = new(v1.LabelSelector)
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Service != nil {
@@ -365,6 +693,21 @@ func (in *ExternalDNSSourceUnion) DeepCopyInto(out *ExternalDNSSourceUnion) {
 		*out = new(ExternalDNSOpenShiftRouteOptions)
 		**out = **in
 	}
+	if in.CRD != nil {
+		in, out := &in.CRD, &out.CRD
+		*out = new(ExternalDNSCRDSourceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(ExternalDNSGatewayRouteSourceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ExternalDNSIngressSourceOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSSourceUnion.
@@ -389,11 +732,22 @@ func (in *ExternalDNSSpec) DeepCopyInto(out *ExternalDNSSpec) {
 	}
 	in.Provider.DeepCopyInto(&out.Provider)
 	in.Source.DeepCopyInto(&out.Source)
+	if in.AdditionalSources != nil {
+		in, out := &in.AdditionalSources, &out.AdditionalSources
+		*out = make([]ExternalDNSSourceUnion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Zones != nil {
 		in, out := &in.Zones, &out.Zones
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.Metrics = in.Metrics
+	in.Registry.DeepCopyInto(&out.Registry)
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	out.Logging = in.Logging
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSSpec.
@@ -411,7 +765,7 @@ func (in *ExternalDNSStatus) DeepCopyInto(out *ExternalDNSStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -421,6 +775,13 @@ func (in *ExternalDNSStatus) DeepCopyInto(out *ExternalDNSStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ZoneStatuses != nil {
+		in, out := &in.ZoneStatuses, &out.ZoneStatuses
+		*out = make([]ExternalDNSZoneStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSStatus.
@@ -433,6 +794,106 @@ func (in *ExternalDNSStatus) DeepCopy() *ExternalDNSStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSTXTRegistryEncryption) DeepCopyInto(out *ExternalDNSTXTRegistryEncryption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSTXTRegistryEncryption.
+func (in *ExternalDNSTXTRegistryEncryption) DeepCopy() *ExternalDNSTXTRegistryEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSTXTRegistryEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSTXTRegistryOptions) DeepCopyInto(out *ExternalDNSTXTRegistryOptions) {
+	*out = *in
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(ExternalDNSTXTRegistryEncryption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSTXTRegistryOptions.
+func (in *ExternalDNSTXTRegistryOptions) DeepCopy() *ExternalDNSTXTRegistryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSTXTRegistryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSWebhookProviderEnvVar) DeepCopyInto(out *ExternalDNSWebhookProviderEnvVar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSWebhookProviderEnvVar.
+func (in *ExternalDNSWebhookProviderEnvVar) DeepCopy() *ExternalDNSWebhookProviderEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSWebhookProviderEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSWebhookProviderOptions) DeepCopyInto(out *ExternalDNSWebhookProviderOptions) {
+	*out = *in
+	out.Credentials = in.Credentials
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]ExternalDNSWebhookProviderEnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSWebhookProviderOptions.
+func (in *ExternalDNSWebhookProviderOptions) DeepCopy() *ExternalDNSWebhookProviderOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSWebhookProviderOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSZoneStatus) DeepCopyInto(out *ExternalDNSZoneStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ManagedRecords != nil {
+		in, out := &in.ManagedRecords, &out.ManagedRecords
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSZoneStatus.
+func (in *ExternalDNSZoneStatus) DeepCopy() *ExternalDNSZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
@@ -38,7 +38,7 @@ sed -i -e "s|quay.io/openshift/origin-external-dns-operator:latest|${OPERATOR_IM
        -e "s|quay.io/openshift/origin-external-dns@.*$|${OPERAND_IMAGE_PULLSPEC}|g" \
        -e "s|quay.io/external-dns-operator/external-dns:.*$|${OPERAND_IMAGE_PULLSPEC}|g" \
        -e "s|quay.io/external-dns-operator/external-dns@.*$|${OPERAND_IMAGE_PULLSPEC}|g" \
-       -e "s|quay.io/openshift/origin-kube-rbac-proxy:latest|${KUBE_RBAC_PROXY_IMAGE_PULLSPEC}|g" "${CSV_FILE}"
+       -e "s|quay.io/openshift/origin-kube-rbac-proxy:.*$|${KUBE_RBAC_PROXY_IMAGE_PULLSPEC}|g" "${CSV_FILE}"
 
 export EPOC_TIMESTAMP=$(date +%s)
 export TARGET_CSV_FILE="${CSV_FILE}"
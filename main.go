@@ -41,7 +41,12 @@ func main() {
 	flag.StringVar(&opCfg.MetricsBindAddress, "metrics-bind-address", operatorconfig.DefaultMetricsAddr, "The address the metric endpoint binds to.")
 	flag.StringVar(&opCfg.OperatorNamespace, "operator-namespace", operatorconfig.DefaultOperatorNamespace, "The namespace that the operator is running in.")
 	flag.StringVar(&opCfg.OperandNamespace, "operand-namespace", operatorconfig.DefaultOperandNamespace, "The namespace that ExternalDNS containers should run in.")
+	flag.Func("allowed-operand-namespaces", "Comma separated list of the namespaces which can be given as the operand namespace of ExternalDNS.", func(value string) error {
+		opCfg.AllowedOperandNamespaces = operatorconfig.SplitNamespaces(value)
+		return nil
+	})
 	flag.StringVar(&opCfg.ExternalDNSImage, "externaldns-image", operatorconfig.DefaultExternalDNSImage, "The container image used for running ExternalDNS.")
+	flag.StringVar(&opCfg.KubeRBACProxyImage, "kube-rbac-proxy-image", operatorconfig.DefaultKubeRBACProxyImage, "The container image used for exposing the metrics of ExternalDNS.")
 	flag.StringVar(&opCfg.CertDir, "cert-dir", operatorconfig.DefaultCertDir, "The directory for keys and certificates for serving the webhook.")
 	flag.StringVar(&opCfg.TrustedCAConfigMapName, "trusted-ca-configmap", operatorconfig.DefaultTrustedCAConfigMapName, "The name of the config map containing TLS CA(s) which should be trusted by ExternalDNS containers. PEM encoded file under \"ca-bundle.crt\" key is expected.")
 	flag.BoolVar(&opCfg.EnableWebhook, "enable-webhook", operatorconfig.DefaultEnableWebhook, "Enable the validating webhook server. Defaults to true.")
@@ -61,6 +66,7 @@ func main() {
 	ctrl.Log.Info("build info", "commit", version.COMMIT)
 	ctrl.Log.Info("using operator namespace", "namespace", opCfg.OperatorNamespace)
 	ctrl.Log.Info("using operand namespace", "namespace", opCfg.OperandNamespace)
+	ctrl.Log.Info("using allowed operand namespaces", "namespaces", opCfg.AllowedOperandNamespaces)
 	ctrl.Log.Info("using ExternalDNS image", "image", opCfg.ExternalDNSImage)
 
 	kubeConfig := ctrl.GetConfigOrDie()
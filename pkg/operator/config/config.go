@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -35,6 +36,7 @@ import (
 
 const (
 	DefaultExternalDNSImage        = "quay.io/external-dns-operator/external-dns:latest"
+	DefaultKubeRBACProxyImage      = "quay.io/openshift/origin-kube-rbac-proxy:4.19"
 	DefaultMetricsAddr             = "127.0.0.1:8080"
 	DefaultOperatorNamespace       = "external-dns-operator"
 	DefaultOperandNamespace        = "external-dns"
@@ -49,6 +51,10 @@ const (
 	openshiftResourceGroup     = "operator.openshift.io"
 	openshiftResourceVersion   = "v1"
 	openshiftClusterConfigName = "cluster"
+
+	// nativeSidecarsMinMinorVersion is the minor version of Kubernetes 1.x
+	// from which the native sidecars are enabled by default.
+	nativeSidecarsMinMinorVersion = 29
 )
 
 var DefaultCertDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
@@ -59,6 +65,10 @@ type Config struct {
 	// by the operator.
 	ExternalDNSImage string
 
+	// KubeRBACProxyImage is the kube-rbac-proxy image for the sidecars
+	// which expose the metrics of the ExternalDNS container(s).
+	KubeRBACProxyImage string
+
 	// MetricsBindAddress is the TCP address that the operator should bind to for
 	// serving prometheus metrics. It can be set to "0" to disable the metrics serving.
 	MetricsBindAddress string
@@ -69,6 +79,10 @@ type Config struct {
 	// OperandNamespace is the namespace that the operator should deploy ExternalDNS container(s) in.
 	OperandNamespace string
 
+	// AllowedOperandNamespaces are the namespaces which can be given as the operand namespace in ExternalDNS spec.
+	// The operator needs to be granted the permissions to manage the operand resources in each of them.
+	AllowedOperandNamespaces []string
+
 	// CertDir is the directory from where the operator loads keys and certificates.
 	CertDir string
 
@@ -84,6 +98,10 @@ type Config struct {
 	// PlatformStatus is the details about the underlying platform.
 	PlatformStatus *configv1.PlatformStatus
 
+	// NativeSidecars is the flag indicating that the cluster runs the init containers
+	// with the Always restart policy as sidecars (Kubernetes 1.29 and later).
+	NativeSidecars bool
+
 	// TrustedCAConfigMapName is the name of the configmap containing CA bundle to be trusted by ExternalDNS containers.
 	TrustedCAConfigMapName string
 
@@ -103,18 +121,20 @@ type Config struct {
 
 // DetectPlatform detects the underlying platform and fills corresponding config fields
 func (c *Config) DetectPlatform(kubeConfig *rest.Config) error {
-	if c.EnablePlatformDetection {
-		kubeClient, err := kubernetes.NewForConfig(kubeConfig)
-		if err != nil {
-			return err
-		}
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return err
+	}
 
+	if c.EnablePlatformDetection {
 		c.IsOpenShift, err = isOCP(kubeClient)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+
+	c.NativeSidecars, err = supportsNativeSidecars(kubeClient)
+	return err
 }
 
 // FillPlatformDetails fills the config with the platform details
@@ -139,6 +159,18 @@ func (c *Config) RequeuePeriod() time.Duration {
 	return time.Duration(c.RequeuePeriodSeconds) * time.Second
 }
 
+// SplitNamespaces returns the namespaces of the given comma separated list.
+// The empty items are skipped.
+func SplitNamespaces(value string) []string {
+	namespaces := []string{}
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 // isOCP returns true if the platform is OCP
 func isOCP(kubeClient discovery.DiscoveryInterface) (bool, error) {
 	// Since, CRD for OpenShift API Server was introduced in OCP v4.x we can verify if the current cluster is on OCP v4.x by
@@ -156,3 +188,21 @@ func isOCP(kubeClient discovery.DiscoveryInterface) (bool, error) {
 	}
 	return false, nil
 }
+
+// supportsNativeSidecars returns true if the Kubernetes version of the cluster enables the native sidecars
+func supportsNativeSidecars(kubeClient discovery.DiscoveryInterface) (bool, error) {
+	info, err := kubeClient.ServerVersion()
+	if err != nil {
+		return false, fmt.Errorf("failed to get the server version: %w", err)
+	}
+	major, err := strconv.Atoi(info.Major)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the major server version %q: %w", info.Major, err)
+	}
+	// some distributions add a suffix to the minor version, e.g. "29+"
+	minor, err := strconv.Atoi(strings.TrimRight(info.Minor, "+"))
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the minor server version %q: %w", info.Minor, err)
+	}
+	return major > 1 || (major == 1 && minor >= nativeSidecarsMinMinorVersion), nil
+}
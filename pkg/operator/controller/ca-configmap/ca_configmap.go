@@ -18,30 +18,70 @@ package ca_configmap
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
 	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
 )
 
-// ensureTrustedCAConfigMap ensures that the source configmap has been copied to the operand namespace.
-// Returns the target configmap, a boolean if the target configmap exists, and an error when relevant.
-func (r *reconciler) ensureTrustedCAConfigMap(ctx context.Context) (bool, *corev1.ConfigMap, error) {
+// ensureTrustedCAConfigMaps ensures that the source configmap has been copied to the operand namespaces:
+// the default one and the ones given in ExternalDNS instances.
+func (r *reconciler) ensureTrustedCAConfigMaps(ctx context.Context) error {
 	// get the source configmap
 	srcName := types.NamespacedName{Namespace: r.config.SourceNamespace, Name: r.config.CAConfigMapName}
 	sourceExists, source, err := r.currentTrustedCAConfigMap(ctx, srcName)
 	if err != nil {
-		return false, nil, err
+		return err
 	} else if !sourceExists {
-		return false, nil, nil
+		return nil
+	}
+
+	namespaces, err := r.operandNamespaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, ns := range namespaces {
+		if _, _, err := r.ensureTrustedCAConfigMap(ctx, source, ns); err != nil {
+			errs = append(errs, fmt.Errorf("failed to ensure the trusted CA configmap in %q namespace: %w", ns, err))
+		}
 	}
+	return utilerrors.NewAggregate(errs)
+}
 
+// operandNamespaces returns the default operand namespace
+// and the operand namespaces given in ExternalDNS instances.
+func (r *reconciler) operandNamespaces(ctx context.Context) ([]string, error) {
+	externalDNSList := &operatorv1beta1.ExternalDNSList{}
+	if err := r.client.List(ctx, externalDNSList); err != nil {
+		return nil, fmt.Errorf("failed to list externalDNS: %w", err)
+	}
+
+	namespaces := []string{r.config.TargetNamespace}
+	seen := map[string]bool{r.config.TargetNamespace: true}
+	for i := range externalDNSList.Items {
+		ns := controller.ExternalDNSOperandNamespace(&externalDNSList.Items[i], r.config.TargetNamespace)
+		if !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, nil
+}
+
+// ensureTrustedCAConfigMap ensures that the source configmap has been copied to the given operand namespace.
+// Returns the target configmap, a boolean if the target configmap exists, and an error when relevant.
+func (r *reconciler) ensureTrustedCAConfigMap(ctx context.Context, source *corev1.ConfigMap, namespace string) (bool, *corev1.ConfigMap, error) {
 	// check if the target configmap exists
-	targetName := controller.ExternalDNSDestTrustedCAConfigMapName(r.config.TargetNamespace)
+	targetName := controller.ExternalDNSDestTrustedCAConfigMapName(namespace)
 	targetExists, target, err := r.currentTrustedCAConfigMap(ctx, targetName)
 	if err != nil {
 		return false, nil, err
@@ -95,6 +135,7 @@ func desiredTrustedCAConfigMap(source *corev1.ConfigMap, targetName types.Namesp
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      targetName.Name,
 			Namespace: targetName.Namespace,
+			Labels:    controller.ExternalDNSOperandLabels(),
 		},
 		Data: source.Data,
 	}
@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
 	extdnscontroller "github.com/openshift/external-dns-operator/pkg/operator/controller"
 	ctrlutils "github.com/openshift/external-dns-operator/pkg/operator/controller/utils"
 )
@@ -57,6 +58,7 @@ type reconciler struct {
 
 // New creates a new controller that syncs the configmap containing trusted CA(s)
 // between the operator and operand namespaces.
+// The operand namespaces given in ExternalDNS instances are synced too.
 func New(mgr manager.Manager, config Config) (controller.Controller, error) {
 	log := ctrl.Log.WithName(controllerName)
 	operatorCache := mgr.GetCache()
@@ -91,12 +93,27 @@ func New(mgr manager.Manager, config Config) (controller.Controller, error) {
 		return nil, err
 	}
 
-	// Watch the configmap from the target namespace
+	// Watch the configmap from the target namespaces
 	// and enqueue the one from the source namespace
 	if err := c.Watch(
 		source.Kind[client.Object](operatorCache, &corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(targetToSource),
-			predicate.And(predicate.NewPredicateFuncs(ctrlutils.InNamespace(config.TargetNamespace)), predicate.NewPredicateFuncs(ctrlutils.HasName(extdnscontroller.ExternalDNSDestTrustedCAConfigMapName("").Name))),
+			predicate.And(
+				predicate.Or(predicate.NewPredicateFuncs(ctrlutils.InNamespace(config.TargetNamespace)), predicate.NewPredicateFuncs(ctrlutils.HasLabel(extdnscontroller.ExternalDNSOperandLabel))),
+				predicate.NewPredicateFuncs(ctrlutils.HasName(extdnscontroller.ExternalDNSDestTrustedCAConfigMapName("").Name)),
+			),
+		)); err != nil {
+		return nil, err
+	}
+
+	// Watch ExternalDNS instances with the operand namespace
+	// and enqueue the configmap from the source namespace to copy it there
+	if err := c.Watch(
+		source.Kind[client.Object](operatorCache, &operatorv1beta1.ExternalDNS{},
+			handler.EnqueueRequestsFromMapFunc(targetToSource),
+			predicate.NewPredicateFuncs(func(o client.Object) bool {
+				return o.(*operatorv1beta1.ExternalDNS).Spec.OperandNamespace != ""
+			}),
 		)); err != nil {
 		return nil, err
 	}
@@ -118,8 +135,8 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, fmt.Errorf("failed to get configmap %q: %w", request.NamespacedName, err)
 	}
 
-	if _, _, err := r.ensureTrustedCAConfigMap(ctx); err != nil {
-		return reconcile.Result{}, fmt.Errorf("failed to ensure the trusted CA configmap: %w", err)
+	if err := r.ensureTrustedCAConfigMaps(ctx); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure the trusted CA configmaps: %w", err)
 	}
 
 	reqLogger.Info("trusted CA configmap is reconciled")
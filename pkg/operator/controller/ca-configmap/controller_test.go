@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
 	test "github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
 )
 
@@ -100,6 +101,23 @@ func TestReconcile(t *testing.T) {
 			inputRequest:    testRequest(),
 			expectedResult:  reconcile.Result{},
 		},
+		{
+			name:            "Bootstrap with operand namespaces",
+			existingObjects: []runtime.Object{testSrcConfigMap(), testTargetConfigMap(), testExtDNSInstance("test-tenant", "tenant-a"), testExtDNSInstance("test", "")},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   "configmap",
+					NamespacedName: types.NamespacedName{
+						Namespace: "tenant-a",
+						Name:      testTargetConfigMapName,
+					},
+				},
+			},
+		},
 		{
 			name:            "Deleted source configmap",
 			existingObjects: []runtime.Object{},
@@ -207,3 +225,14 @@ func testDriftedConfigMap() *corev1.ConfigMap {
 		},
 	}
 }
+
+func testExtDNSInstance(name, operandNamespace string) *operatorv1beta1.ExternalDNS {
+	return &operatorv1beta1.ExternalDNS{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: operatorv1beta1.ExternalDNSSpec{
+			OperandNamespace: operandNamespace,
+		},
+	}
+}
@@ -19,6 +19,7 @@ package credentials_secret
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -91,20 +92,22 @@ func New(mgr manager.Manager, config Config) (controller.Controller, error) {
 			&handler.EnqueueRequestForObject{},
 			predicate.Funcs{
 				CreateFunc: func(e event.CreateEvent) bool {
-					return hasSecret(e.Object, config.IsOpenShift)
+					return hasSecret(e.Object, config.IsOpenShift) || hasRenderedConfig(e.Object)
 				},
 				DeleteFunc: func(e event.DeleteEvent) bool {
-					return hasSecret(e.Object, config.IsOpenShift)
+					return hasSecret(e.Object, config.IsOpenShift) || hasRenderedConfig(e.Object)
 				},
 				UpdateFunc: func(e event.UpdateEvent) bool {
 					oldED := e.ObjectOld.(*operatorv1beta1.ExternalDNS)
 					newED := e.ObjectNew.(*operatorv1beta1.ExternalDNS)
 					oldName := getExternalDNSCredentialsSecretName(oldED, config.IsOpenShift)
 					newName := getExternalDNSCredentialsSecretName(newED, config.IsOpenShift)
-					return oldName != newName || oldED.DeletionTimestamp != newED.DeletionTimestamp
+					// the rendered config follows the provider options
+					renderedConfigChanged := hasRenderedConfig(newED) && !reflect.DeepEqual(oldED.Spec.Provider, newED.Spec.Provider)
+					return oldName != newName || oldED.DeletionTimestamp != newED.DeletionTimestamp || renderedConfigChanged
 				},
 				GenericFunc: func(e event.GenericEvent) bool {
-					return hasSecret(e.Object, config.IsOpenShift)
+					return hasSecret(e.Object, config.IsOpenShift) || hasRenderedConfig(e.Object)
 				},
 			},
 		)); err != nil {
@@ -119,10 +122,14 @@ func New(mgr manager.Manager, config Config) (controller.Controller, error) {
 		credentialsSecretIndexFieldName,
 		client.IndexerFunc(func(o client.Object) []string {
 			ed := o.(*operatorv1beta1.ExternalDNS)
-			name := getExternalDNSCredentialsSecretName(ed, config.IsOpenShift)
+			name, fromCR := getExternalDNSCredentialsSecretNameWithTrace(ed, config.IsOpenShift)
 			if len(name) == 0 {
 				return []string{}
 			}
+			if !fromCR {
+				// the shared secret is the fallback of the one provisioned for the instance
+				return []string{name, extdnscontroller.SecretFromCloudCredentialsOperator}
+			}
 			return []string{name}
 		}),
 	); err != nil {
@@ -198,14 +205,19 @@ func New(mgr manager.Manager, config Config) (controller.Controller, error) {
 		return nil, err
 	}
 
-	// Watch secrets from the target namespace
+	// Watch secrets from the target namespaces
 	// and if a secret was indexed as belonging to ExternalDNS
 	// we send the reconcile requests with all the ExternalDNS resources
 	// which referenced it
 	if err := c.Watch(
 		source.Kind[client.Object](operatorCache, &corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(credSecretToExtDNSTargetNS),
-			predicate.NewPredicateFuncs(ctrlutils.InNamespace(config.TargetNamespace)),
+			predicate.Or(
+				// default target namespace
+				predicate.NewPredicateFuncs(ctrlutils.InNamespace(config.TargetNamespace)),
+				// operand namespaces given in ExternalDNS spec
+				predicate.NewPredicateFuncs(ctrlutils.HasLabel(extdnscontroller.ExternalDNSOperandLabel)),
+			),
 		)); err != nil {
 		return nil, err
 	}
@@ -234,6 +246,16 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		Name:      srcSecretNameOnly,
 	}
 
+	if !fromCR && len(srcSecretNameOnly) != 0 {
+		// the secret of the instance is not provisioned by the cloud credentials operator yet
+		// or the operator runs in the manual mode: the shared secret is used if it exists
+		if exists, _, err := r.currentCredentialsSecret(ctx, srcSecretName); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to get the source credentials secret for externalDNS %q: %w", extDNS.Name, err)
+		} else if !exists {
+			srcSecretName.Name = extdnscontroller.SecretFromCloudCredentialsOperator
+		}
+	}
+
 	if _, _, err := r.ensureCredentialsSecret(ctx, srcSecretName, extDNS, fromCR); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to ensure credentials secret for externalDNS %q: %w", extDNS.Name, err)
 	}
@@ -249,6 +271,11 @@ func hasSecret(o client.Object, isOpenShift bool) bool {
 	return len(getExternalDNSCredentialsSecretName(ed, isOpenShift)) != 0
 }
 
+// hasRenderedConfig returns true if the operator renders the provider config of ExternalDNS
+func hasRenderedConfig(o client.Object) bool {
+	return operatorutils.WorkloadIdentityConfigProvider(o.(*operatorv1beta1.ExternalDNS))
+}
+
 // getExternalDNSCredentialsSecretName returns the name of the credentials secret which should be used as source
 func getExternalDNSCredentialsSecretName(externalDNS *operatorv1beta1.ExternalDNS, isOpenShift bool) string {
 	name, _ := getExternalDNSCredentialsSecretNameWithTrace(externalDNS, isOpenShift)
@@ -263,7 +290,7 @@ func getExternalDNSCredentialsSecretNameWithTrace(externalDNS *operatorv1beta1.E
 	}
 
 	if isOpenShift && operatorutils.ManagedCredentialsProvider(externalDNS) {
-		return extdnscontroller.SecretFromCloudCredentialsOperator, false
+		return extdnscontroller.ExternalDNSCloudCredentialsSecretName(externalDNS), false
 	}
 
 	return "", false
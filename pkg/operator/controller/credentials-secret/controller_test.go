@@ -45,10 +45,11 @@ import (
 const (
 	testOperatorNamespace    = "external-dns-operator"
 	testOperandNamespace     = "external-dns"
+	testTenantNamespace      = "tenant-a"
 	testExtDNSName           = "test"
 	testSrcSecretName        = "testsecret"
 	testTargetSecretName     = "external-dns-credentials-test"
-	testSrcSecretNameWhenOCP = "externaldns-cloud-credentials"
+	testSrcSecretNameWhenOCP = "externaldns-cloud-credentials-test"
 )
 
 func TestReconcile(t *testing.T) {
@@ -83,6 +84,23 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:            "Bootstrap with operand namespace",
+			existingObjects: []runtime.Object{testAWSExtDNSInstanceWithOperandNamespace(), testSrcSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   "secret",
+					NamespacedName: types.NamespacedName{
+						Namespace: testTenantNamespace,
+						Name:      testTargetSecretName,
+					},
+				},
+			},
+		},
 		{
 			name:            "Target secret drifted",
 			existingObjects: []runtime.Object{testAWSExtDNSInstance(), testSrcSecret(), testDriftedTargetSecret()},
@@ -146,6 +164,44 @@ func TestReconcile(t *testing.T) {
 			expectedResult:  reconcile.Result{},
 			errExpected:     true,
 		},
+		{
+			name:            "Target secret has expected keys for Cloudflare provider",
+			existingObjects: []runtime.Object{testCloudflareExtDNSInstance(), testCloudflareSrcSecret(), testCloudflareTargetSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+		},
+		{
+			name:            "Target secret doesn't have expected keys for Cloudflare provider",
+			existingObjects: []runtime.Object{testCloudflareExtDNSInstance(), testCloudflareWrongSrcSecret(), testCloudflareTargetSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			errExpected:     true,
+		},
+		{
+			name:            "Target secret has expected keys for RFC2136 provider",
+			existingObjects: []runtime.Object{testRFC2136ExtDNSInstance(), testRFC2136SrcSecret(), testRFC2136TargetSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+		},
+		{
+			name:            "Target secret doesn't have expected keys for RFC2136 provider",
+			existingObjects: []runtime.Object{testRFC2136ExtDNSInstance(), testRFC2136WrongSrcSecret(), testRFC2136TargetSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			errExpected:     true,
+		},
+		{
+			name:            "Target secret doesn't have Kerberos keys for RFC2136 provider with GSS-TSIG",
+			existingObjects: []runtime.Object{testRFC2136GSSTSIGExtDNSInstance(), testRFC2136SrcSecret(), testRFC2136TargetSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			errExpected:     true,
+		},
 		{
 			name:            "Target secret has expected keys for Azure provider",
 			existingObjects: []runtime.Object{testAzureExtDNSInstance(), testAzureSrcSecret(), testAzureTargetSecret()},
@@ -209,6 +265,24 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Bootstrap when platform is OCP and only the shared secret is provided",
+			// externaldns without credentials specified + shared secret of the previous versions or of the manual mode
+			existingObjects: []runtime.Object{testAWSExtDNSInstanceRouteSource(), testSharedSrcSecretWhenPlatformOCP()},
+			inputConfig:     testConfigOpenShift(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   "secret",
+					NamespacedName: types.NamespacedName{
+						Namespace: testOperandNamespace,
+						Name:      testTargetSecretName,
+					},
+				},
+			},
+		},
 		{
 			name: "Bootstrap when platform is OCP and the credentials are provided explicitly",
 			// externaldns with credentials, platform secret is not there but we don't care as there is one given in CR
@@ -227,6 +301,23 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:            "Bootstrap when Azure workload identity is used",
+			existingObjects: []runtime.Object{testAzureWorkloadIdentityExtDNSInstance()},
+			inputConfig:     testConfigOpenShift(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   "secret",
+					NamespacedName: types.NamespacedName{
+						Namespace: testOperandNamespace,
+						Name:      testTargetSecretName,
+					},
+				},
+			},
+		},
 		{
 			name:            "Deleted ExternalDNS",
 			existingObjects: []runtime.Object{},
@@ -316,23 +407,38 @@ func TestGetExternalDNSCredentialsSecretName(t *testing.T) {
 			inputExtDNS: testInfobloxExtDNSInstance(),
 			expected:    testSrcSecretName,
 		},
+		{
+			name:        "Cloudflare",
+			inputExtDNS: testCloudflareExtDNSInstance(),
+			expected:    testSrcSecretName,
+		},
+		{
+			name:        "RFC2136",
+			inputExtDNS: testRFC2136ExtDNSInstance(),
+			expected:    testSrcSecretName,
+		},
+		{
+			name:        "Webhook",
+			inputExtDNS: testWebhookExtDNSInstance(),
+			expected:    testSrcSecretName,
+		},
 		{
 			name:             "AWS OpenShift",
 			inputExtDNS:      testAWSExtDNSInstanceNoSecret(),
 			inputIsOpenShift: true,
-			expected:         extdnscontroller.SecretFromCloudCredentialsOperator,
+			expected:         extdnscontroller.ExternalDNSCloudCredentialsSecretName(testAWSExtDNSInstanceNoSecret()),
 		},
 		{
 			name:             "Azure OpenShift",
 			inputExtDNS:      testAzureExtDNSInstanceNoSecret(),
 			inputIsOpenShift: true,
-			expected:         extdnscontroller.SecretFromCloudCredentialsOperator,
+			expected:         extdnscontroller.ExternalDNSCloudCredentialsSecretName(testAzureExtDNSInstanceNoSecret()),
 		},
 		{
 			name:             "GCP OpenShift",
 			inputExtDNS:      testGCPExtDNSInstanceNoSecret(),
 			inputIsOpenShift: true,
-			expected:         extdnscontroller.SecretFromCloudCredentialsOperator,
+			expected:         extdnscontroller.ExternalDNSCloudCredentialsSecretName(testGCPExtDNSInstanceNoSecret()),
 		},
 		{
 			name:             "AWS OpenShift with explicit credentials",
@@ -409,6 +515,65 @@ func TestHasSecret(t *testing.T) {
 	}
 }
 
+func TestDesiredCredentialsSecretWorkloadIdentity(t *testing.T) {
+	awsWebIdentity := testAWSExtDNSInstance()
+	awsWebIdentity.Spec.Provider.AWS.CredentialsMode = operatorv1beta1.AWSCredentialsModeWebIdentity
+
+	testCases := []struct {
+		name         string
+		inputExtDNS  *operatorv1beta1.ExternalDNS
+		inputSource  *corev1.Secret
+		expectedData map[string][]byte
+	}{
+		{
+			name:        "Azure config is rendered without client secret",
+			inputExtDNS: testAzureWorkloadIdentityExtDNSInstance(),
+			inputSource: &corev1.Secret{},
+			expectedData: map[string][]byte{
+				"azure.json": []byte(`{"aadClientId":"client","resourceGroup":"dns","subscriptionId":"subscription","tenantId":"tenant","useWorkloadIdentityExtension":true}`),
+			},
+		},
+		{
+			name:        "GCP external account config is rendered",
+			inputExtDNS: testGCPWorkloadIdentityFederationExtDNSInstance(),
+			inputSource: &corev1.Secret{},
+			expectedData: map[string][]byte{
+				"gcp-credentials.json": []byte(`{"type":"external_account",` +
+					`"audience":"//iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/pool/providers/provider",` +
+					`"subject_token_type":"urn:ietf:params:oauth:token-type:jwt",` +
+					`"token_url":"https://sts.googleapis.com/v1/token",` +
+					`"service_account_impersonation_url":"https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/external-dns@my-project.iam.gserviceaccount.com:generateAccessToken",` +
+					`"credential_source":{"file":"/var/run/secrets/gcp/serviceaccount/token","format":{"type":"text"}}}`),
+			},
+		},
+		{
+			name:        "AWS keys not related to the provider are copied",
+			inputExtDNS: awsWebIdentity,
+			inputSource: &corev1.Secret{
+				Data: map[string][]byte{
+					"txt-encryption-key": []byte("key"),
+				},
+			},
+			expectedData: map[string][]byte{
+				"txt-encryption-key": []byte("key"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			destName := types.NamespacedName{Namespace: testOperandNamespace, Name: testTargetSecretName}
+			got, err := desiredCredentialsSecret(tc.inputSource, destName, tc.inputExtDNS, true, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedData, got.Data); diff != "" {
+				t.Errorf("unexpected secret data (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func testConfig() Config {
 	return Config{
 		SourceNamespace: testOperatorNamespace,
@@ -483,6 +648,12 @@ func testAWSExtDNSInstance() *operatorv1beta1.ExternalDNS {
 	return extDNS
 }
 
+func testAWSExtDNSInstanceWithOperandNamespace() *operatorv1beta1.ExternalDNS {
+	extDNS := testAWSExtDNSInstance()
+	extDNS.Spec.OperandNamespace = testTenantNamespace
+	return extDNS
+}
+
 func testAWSExtDNSInstanceRouteSource() *operatorv1beta1.ExternalDNS {
 	extDNS := testExtDNSInstanceforOCPRouteSource()
 	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
@@ -526,6 +697,38 @@ func testAzureExtDNSInstance() *operatorv1beta1.ExternalDNS {
 	return extDNS
 }
 
+func testAzureWorkloadIdentityExtDNSInstance() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstance()
+	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeAzure,
+		Azure: &operatorv1beta1.ExternalDNSAzureProviderOptions{
+			CredentialsMode: operatorv1beta1.AzureCredentialsModeWorkloadIdentity,
+			WorkloadIdentity: &operatorv1beta1.ExternalDNSAzureWorkloadIdentityOptions{
+				ClientID:       "client",
+				TenantID:       "tenant",
+				SubscriptionID: "subscription",
+				ResourceGroup:  "dns",
+			},
+		},
+	}
+	return extDNS
+}
+
+func testGCPWorkloadIdentityFederationExtDNSInstance() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstance()
+	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeGCP,
+		GCP: &operatorv1beta1.ExternalDNSGCPProviderOptions{
+			CredentialsMode: operatorv1beta1.GCPCredentialsModeWorkloadIdentityFederation,
+			WorkloadIdentityFederation: &operatorv1beta1.ExternalDNSGCPWorkloadIdentityFederationOptions{
+				Audience:            "//iam.googleapis.com/projects/123456789/locations/global/workloadIdentityPools/pool/providers/provider",
+				ServiceAccountEmail: "external-dns@my-project.iam.gserviceaccount.com",
+			},
+		},
+	}
+	return extDNS
+}
+
 func testAzureExtDNSInstanceNoSecret() *operatorv1beta1.ExternalDNS {
 	extDNS := testExtDNSInstance()
 	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
@@ -673,6 +876,133 @@ func testInfoBloxTargetSecret() *corev1.Secret {
 	}
 }
 
+// Cloudflare
+func testCloudflareExtDNSInstance() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstance()
+	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeCloudflare,
+		Cloudflare: &operatorv1beta1.ExternalDNSCloudflareProviderOptions{
+			Credentials: operatorv1beta1.SecretReference{
+				Name: testSrcSecretName,
+			},
+		},
+	}
+	return extDNS
+}
+
+func testCloudflareSrcSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSrcSecretName,
+			Namespace: testOperatorNamespace,
+		},
+		Data: map[string][]byte{
+			"CF_API_TOKEN": []byte("val1"),
+		},
+	}
+}
+
+func testCloudflareWrongSrcSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSrcSecretName,
+			Namespace: testOperatorNamespace,
+		},
+		Data: map[string][]byte{
+			"CF_API_KEY": []byte("val1"),
+		},
+	}
+}
+
+func testCloudflareTargetSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testTargetSecretName,
+			Namespace: testOperandNamespace,
+		},
+		Data: map[string][]byte{
+			"CF_API_TOKEN": []byte("val1"),
+		},
+	}
+}
+
+// RFC2136
+func testRFC2136ExtDNSInstance() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstance()
+	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeRFC2136,
+		RFC2136: &operatorv1beta1.ExternalDNSRFC2136ProviderOptions{
+			Credentials: operatorv1beta1.SecretReference{
+				Name: testSrcSecretName,
+			},
+			Host:        "dns.example.com",
+			Zone:        "example.com",
+			TSIGKeyName: "externaldns-key",
+		},
+	}
+	return extDNS
+}
+
+func testRFC2136GSSTSIGExtDNSInstance() *operatorv1beta1.ExternalDNS {
+	extDNS := testRFC2136ExtDNSInstance()
+	extDNS.Spec.Provider.RFC2136.TSIGKeyName = ""
+	extDNS.Spec.Provider.RFC2136.GSSTSIG = &operatorv1beta1.ExternalDNSRFC2136GSSTSIGOptions{
+		KerberosRealm: "EXAMPLE.COM",
+	}
+	return extDNS
+}
+
+func testRFC2136SrcSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSrcSecretName,
+			Namespace: testOperatorNamespace,
+		},
+		Data: map[string][]byte{
+			"EXTERNAL_DNS_RFC2136_TSIG_SECRET": []byte("val1"),
+		},
+	}
+}
+
+func testRFC2136WrongSrcSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testSrcSecretName,
+			Namespace: testOperatorNamespace,
+		},
+		Data: map[string][]byte{
+			"TSIG_SECRET": []byte("val1"),
+		},
+	}
+}
+
+func testRFC2136TargetSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testTargetSecretName,
+			Namespace: testOperandNamespace,
+		},
+		Data: map[string][]byte{
+			"EXTERNAL_DNS_RFC2136_TSIG_SECRET": []byte("val1"),
+		},
+	}
+}
+
+// Webhook
+func testWebhookExtDNSInstance() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstance()
+	extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeWebhook,
+		Webhook: &operatorv1beta1.ExternalDNSWebhookProviderOptions{
+			Credentials: operatorv1beta1.SecretReference{
+				Name: testSrcSecretName,
+			},
+			Image: "quay.io/test/external-dns-webhook:latest",
+		},
+	}
+	return extDNS
+}
+
 // GCP
 
 func testGCPExtDNSInstance() *operatorv1beta1.ExternalDNS {
@@ -770,6 +1100,12 @@ func testSrcSecretWhenPlatformOCP() *corev1.Secret {
 	}
 }
 
+func testSharedSrcSecretWhenPlatformOCP() *corev1.Secret {
+	secret := testSrcSecretWhenPlatformOCP()
+	secret.Name = extdnscontroller.SecretFromCloudCredentialsOperator
+	return secret
+}
+
 func testTargetSecret() *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -32,20 +32,28 @@ import (
 
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
 	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	operatorutils "github.com/openshift/external-dns-operator/pkg/utils"
 )
 
 // ensureCredentialsSecret ensures that the source secret has been copied to the operand namespace.
 // Returns the destination secret, a boolean if the destination secret exists, and an error when relevant.
 func (r *reconciler) ensureCredentialsSecret(ctx context.Context, sourceName types.NamespacedName, extDNS *operatorv1beta1.ExternalDNS, fromCR bool) (bool, *corev1.Secret, error) {
 	// get the source secret
-	sourceExists, source, err := r.currentCredentialsSecret(ctx, sourceName)
-	if err != nil {
-		return false, nil, err
-	} else if !sourceExists {
+	source := &corev1.Secret{}
+	if len(sourceName.Name) != 0 {
+		sourceExists, current, err := r.currentCredentialsSecret(ctx, sourceName)
+		if err != nil {
+			return false, nil, err
+		} else if !sourceExists {
+			return false, nil, nil
+		}
+		source = current
+	} else if !operatorutils.WorkloadIdentityConfigProvider(extDNS) {
+		// nothing to copy and nothing to render
 		return false, nil, nil
 	}
 
-	destName := controller.ExternalDNSDestCredentialsSecretName(r.config.TargetNamespace, extDNS.Name)
+	destName := controller.ExternalDNSDestCredentialsSecretName(controller.ExternalDNSOperandNamespace(extDNS, r.config.TargetNamespace), extDNS.Name)
 	// desired is created from source
 	desired, err := desiredCredentialsSecret(source, destName, extDNS, r.config.IsOpenShift, fromCR)
 	if err != nil {
@@ -106,10 +114,41 @@ func desiredCredentialsSecret(sourceSecret *corev1.Secret, destName types.Namesp
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      destName.Name,
 			Namespace: destName.Namespace,
+			Labels:    controller.ExternalDNSOperandLabels(),
 		},
 		Data: map[string][]byte{},
 	}
 
+	if operatorutils.WorkloadIdentityProvider(extDNS) {
+		// the provider authenticates with the service account token:
+		// copy the keys not related to the provider and render the provider config from the spec
+		for k, v := range sourceSecret.Data {
+			secret.Data[k] = v
+		}
+		if extDNS.Spec.Provider.Type == operatorv1beta1.ProviderTypeAzure && extDNS.Spec.Provider.Azure.WorkloadIdentity != nil {
+			wi := extDNS.Spec.Provider.Azure.WorkloadIdentity
+			azureConfig, err := json.Marshal(map[string]interface{}{
+				"aadClientId":                  wi.ClientID,
+				"resourceGroup":                wi.ResourceGroup,
+				"subscriptionId":               wi.SubscriptionID,
+				"tenantId":                     wi.TenantID,
+				"useWorkloadIdentityExtension": true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to render azure config: %w", err)
+			}
+			secret.Data["azure.json"] = azureConfig
+		}
+		if extDNS.Spec.Provider.Type == operatorv1beta1.ProviderTypeGCP && extDNS.Spec.Provider.GCP.WorkloadIdentityFederation != nil {
+			gcpConfig, err := json.Marshal(newGCPExternalAccountConfig(extDNS.Spec.Provider.GCP.WorkloadIdentityFederation))
+			if err != nil {
+				return nil, fmt.Errorf("failed to render gcp credentials config: %w", err)
+			}
+			secret.Data["gcp-credentials.json"] = gcpConfig
+		}
+		return secret, nil
+	}
+
 	if isOpenShift && !fromCR {
 		// secret came from CCO: use CCO fields
 		switch extDNS.Spec.Provider.Type {
@@ -155,6 +194,21 @@ func desiredCredentialsSecret(sourceSecret *corev1.Secret, destName types.Namesp
 		if password, exists := sourceSecret.Data["EXTERNAL_DNS_INFOBLOX_WAPI_PASSWORD"]; !exists || len(password) == 0 {
 			return nil, fmt.Errorf("invalid credentials for infoblox: password not found")
 		}
+	case operatorv1beta1.ProviderTypeCloudflare:
+		if token, exists := sourceSecret.Data["CF_API_TOKEN"]; !exists || len(token) == 0 {
+			return nil, fmt.Errorf("invalid credentials for cloudflare: API token not found")
+		}
+	case operatorv1beta1.ProviderTypeRFC2136:
+		if extDNS.Spec.Provider.RFC2136 != nil && extDNS.Spec.Provider.RFC2136.GSSTSIG != nil {
+			if username, exists := sourceSecret.Data["EXTERNAL_DNS_RFC2136_KERBEROS_USERNAME"]; !exists || len(username) == 0 {
+				return nil, fmt.Errorf("invalid credentials for rfc2136: kerberos username not found")
+			}
+			if password, exists := sourceSecret.Data["EXTERNAL_DNS_RFC2136_KERBEROS_PASSWORD"]; !exists || len(password) == 0 {
+				return nil, fmt.Errorf("invalid credentials for rfc2136: kerberos password not found")
+			}
+		} else if tsigSecret, exists := sourceSecret.Data["EXTERNAL_DNS_RFC2136_TSIG_SECRET"]; !exists || len(tsigSecret) == 0 {
+			return nil, fmt.Errorf("invalid credentials for rfc2136: TSIG secret not found")
+		}
 	case operatorv1beta1.ProviderTypeAzure:
 		if config, exists := sourceSecret.Data["azure.json"]; !exists || len(config) == 0 {
 			return nil, fmt.Errorf("invalid config for azure")
@@ -193,6 +247,46 @@ func (r *reconciler) updateCredentialsSecret(ctx context.Context, current, desir
 func secretsEqual(a, b *corev1.Secret) bool {
 	return reflect.DeepEqual(a.Data, b.Data)
 }
+
+// gcpExternalAccountConfig is the credential config of GCP workload identity federation.
+// https://google.aip.dev/auth/4117
+type gcpExternalAccountConfig struct {
+	Type                           string                             `json:"type"`
+	Audience                       string                             `json:"audience"`
+	SubjectTokenType               string                             `json:"subject_token_type"`
+	TokenURL                       string                             `json:"token_url"`
+	ServiceAccountImpersonationURL string                             `json:"service_account_impersonation_url,omitempty"`
+	CredentialSource               gcpExternalAccountCredentialSource `json:"credential_source"`
+}
+
+type gcpExternalAccountCredentialSource struct {
+	File   string                       `json:"file"`
+	Format gcpExternalAccountFileFormat `json:"format"`
+}
+
+type gcpExternalAccountFileFormat struct {
+	Type string `json:"type"`
+}
+
+// newGCPExternalAccountConfig returns the credential config which exchanges
+// the projected service account token using the given workload identity pool provider.
+func newGCPExternalAccountConfig(wif *operatorv1beta1.ExternalDNSGCPWorkloadIdentityFederationOptions) gcpExternalAccountConfig {
+	config := gcpExternalAccountConfig{
+		Type:             "external_account",
+		Audience:         wif.Audience,
+		SubjectTokenType: "urn:ietf:params:oauth:token-type:jwt",
+		TokenURL:         "https://sts.googleapis.com/v1/token",
+		CredentialSource: gcpExternalAccountCredentialSource{
+			File:   controller.GCPWorkloadIdentityTokenMountPath + "/" + controller.GCPWorkloadIdentityTokenPath,
+			Format: gcpExternalAccountFileFormat{Type: "text"},
+		},
+	}
+	if wif.ServiceAccountEmail != "" {
+		config.ServiceAccountImpersonationURL = fmt.Sprintf("https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/%s:generateAccessToken", wif.ServiceAccountEmail)
+	}
+	return config
+}
+
 func newConfigForStaticCreds(accessKey string, accessSecret string) []byte {
 	buf := &bytes.Buffer{}
 	fmt.Fprint(buf, "[default]\n")
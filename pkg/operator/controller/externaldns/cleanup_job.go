@@ -0,0 +1,138 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+const (
+	// cleanupJobBackoffLimit is the number of retries of the cleanup pod
+	// before the cleanup job is considered as failed.
+	cleanupJobBackoffLimit = 3
+)
+
+// ensureExternalDNSCleanupJob ensures that the job which cleans up the DNS records of the given externalDNS exists.
+// The job is never updated: the provider configuration cannot change once the externalDNS is being deleted.
+// Returns a Boolean value indicating whether the job exists, a pointer to the job, and an error when relevant.
+func (r *reconciler) ensureExternalDNSCleanupJob(ctx context.Context, namespace, image string, serviceAccount *corev1.ServiceAccount, credSecret *corev1.Secret, trustCAConfigMap *corev1.ConfigMap, externalDNS *operatorv1beta1.ExternalDNS) (bool, *batchv1.Job, error) {
+	nsName := types.NamespacedName{Namespace: namespace, Name: controller.ExternalDNSCleanupJobName(externalDNS)}
+
+	exist, current, err := r.currentExternalDNSCleanupJob(ctx, nsName)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to get externalDNS cleanup job: %w", err)
+	}
+	if exist {
+		return true, current, nil
+	}
+
+	trustCAConfigMapName := ""
+	if trustCAConfigMap != nil {
+		trustCAConfigMapName = trustCAConfigMap.Name
+	}
+
+	// the job doesn't need the hashes which trigger the rollouts of the deployment
+	// nor the metrics proxy
+	desired, err := desiredExternalDNSCleanupJob(&deploymentConfig{
+		namespace:              namespace,
+		image:                  image,
+		serviceAccount:         serviceAccount,
+		externalDNS:            externalDNS,
+		isOpenShift:            r.config.IsOpenShift,
+		platformStatus:         r.config.PlatformStatus,
+		secret:                 credSecret.Name,
+		trustedCAConfigMapName: trustCAConfigMapName,
+	})
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to build externalDNS cleanup job: %w", err)
+	}
+
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return false, nil, fmt.Errorf("failed to set the controller reference for cleanup job: %w", err)
+	}
+
+	if err := r.client.Create(ctx, desired); err != nil {
+		return false, nil, fmt.Errorf("failed to create externalDNS cleanup job %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+	r.log.Info("created externalDNS cleanup job", "namespace", desired.Namespace, "name", desired.Name)
+
+	return r.currentExternalDNSCleanupJob(ctx, nsName)
+}
+
+// currentExternalDNSCleanupJob gets the current externalDNS cleanup job resource.
+func (r *reconciler) currentExternalDNSCleanupJob(ctx context.Context, nsName types.NamespacedName) (bool, *batchv1.Job, error) {
+	job := &batchv1.Job{}
+	if err := r.client.Get(ctx, nsName, job); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, job, nil
+}
+
+// desiredExternalDNSCleanupJob returns the desired cleanup job resource.
+// The job runs ExternalDNS with the same provider configuration as the operand deployment
+// but with the source which doesn't produce any endpoint, which makes ExternalDNS delete all the records it owns.
+func desiredExternalDNSCleanupJob(cfg *deploymentConfig) (*batchv1.Job, error) {
+	podSpec, err := desiredExternalDNSPodSpec(cfg, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      controller.ExternalDNSCleanupJobName(cfg.externalDNS),
+			Namespace: cfg.namespace,
+			Labels:    controller.ExternalDNSOperandLabels(),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](cleanupJobBackoffLimit),
+			Template: corev1.PodTemplateSpec{
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
+
+// cleanupJobFinished returns whether the given job finished and whether it succeeded.
+func cleanupJobFinished(job *batchv1.Job) (bool, bool) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return true, true
+		case batchv1.JobFailed:
+			return true, false
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestDesiredExternalDNSCleanupJob(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		inputSecretName        string
+		inputExternalDNS       *operatorv1beta1.ExternalDNS
+		expectedContainersArgs map[string][]string
+		expectedInitContainers []string
+	}{
+		{
+			name:             "AWS with zone",
+			inputSecretName:  awsSecret,
+			inputExternalDNS: testAWSExternalDNSZones([]string{test.PublicZone}, operatorv1beta1.SourceTypeService),
+			expectedContainersArgs: map[string][]string{
+				ExternalDNSContainerName: {
+					"--metrics-address=127.0.0.1:7979",
+					"--txt-owner-id=external-dns-test",
+					"--provider=aws",
+					"--policy=sync",
+					"--registry=txt",
+					"--log-level=debug",
+					"--zone-id-filter=" + test.PublicZone,
+					"--source=empty",
+					"--once",
+					"--txt-prefix=external-dns-",
+				},
+			},
+		},
+		{
+			name:             "AWS with UpsertOnly policy",
+			inputSecretName:  awsSecret,
+			inputExternalDNS: testAWSExternalDNSPolicy(operatorv1beta1.SourceTypeService, operatorv1beta1.PolicyUpsertOnly),
+			expectedContainersArgs: map[string][]string{
+				ExternalDNSContainerName: {
+					"--metrics-address=127.0.0.1:7979",
+					"--txt-owner-id=external-dns-test",
+					"--provider=aws",
+					"--policy=sync",
+					"--registry=txt",
+					"--log-level=debug",
+					"--zone-id-filter=" + test.PublicZone,
+					"--source=empty",
+					"--once",
+					"--txt-prefix=external-dns-",
+				},
+			},
+		},
+		{
+			name:             "AWS with dry run",
+			inputSecretName:  awsSecret,
+			inputExternalDNS: testAWSExternalDNSDryRun(operatorv1beta1.SourceTypeService),
+			expectedContainersArgs: map[string][]string{
+				ExternalDNSContainerName: {
+					"--metrics-address=127.0.0.1:7979",
+					"--txt-owner-id=external-dns-test",
+					"--provider=aws",
+					"--policy=sync",
+					"--registry=txt",
+					"--log-level=debug",
+					"--zone-id-filter=" + test.PublicZone,
+					"--dry-run",
+					"--source=empty",
+					"--once",
+					"--txt-prefix=external-dns-",
+				},
+			},
+		},
+		{
+			name:             "Webhook",
+			inputSecretName:  webhookSecret,
+			inputExternalDNS: testWebhookExternalDNS(operatorv1beta1.SourceTypeService),
+			expectedContainersArgs: map[string][]string{
+				ExternalDNSContainerNoZones: {
+					"--metrics-address=127.0.0.1:7979",
+					"--txt-owner-id=external-dns-test",
+					"--provider=webhook",
+					"--policy=sync",
+					"--registry=txt",
+					"--log-level=debug",
+					"--source=empty",
+					"--once",
+					"--webhook-provider-url=http://localhost:8888",
+					"--txt-prefix=external-dns-",
+				},
+			},
+			expectedInitContainers: []string{webhookProviderContainerName},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serviceAccount := &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: test.OperandName,
+				},
+			}
+			job, err := desiredExternalDNSCleanupJob(&deploymentConfig{
+				namespace:      test.OperandNamespace,
+				image:          test.OperandImage,
+				serviceAccount: serviceAccount,
+				externalDNS:    tc.inputExternalDNS,
+				secret:         tc.inputSecretName,
+			})
+			if err != nil {
+				t.Fatalf("expected no error from calling desiredExternalDNSCleanupJob, but received %v", err)
+			}
+
+			if job.Name != controller.ExternalDNSCleanupJobName(tc.inputExternalDNS) || job.Namespace != test.OperandNamespace {
+				t.Errorf("unexpected job name: %s/%s", job.Namespace, job.Name)
+			}
+			if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+				t.Errorf("expected restart policy %q, got %q", corev1.RestartPolicyNever, job.Spec.Template.Spec.RestartPolicy)
+			}
+			if job.Spec.Template.Spec.ServiceAccountName != test.OperandName {
+				t.Errorf("expected service account %q, got %q", test.OperandName, job.Spec.Template.Spec.ServiceAccountName)
+			}
+
+			gotContainersArgs := map[string][]string{}
+			for _, c := range job.Spec.Template.Spec.Containers {
+				gotContainersArgs[c.Name] = c.Args
+			}
+			if diff := cmp.Diff(tc.expectedContainersArgs, gotContainersArgs); diff != "" {
+				t.Errorf("unexpected container args (-want +got):\n%s", diff)
+			}
+
+			var gotInitContainers []string
+			for _, c := range job.Spec.Template.Spec.InitContainers {
+				if c.RestartPolicy == nil || *c.RestartPolicy != corev1.ContainerRestartPolicyAlways {
+					t.Errorf("expected init container %q to be a sidecar", c.Name)
+				}
+				// the cleanup containers must not start before the sidecar listens
+				if c.StartupProbe == nil || c.StartupProbe.TCPSocket == nil {
+					t.Errorf("expected init container %q to have a TCP startup probe", c.Name)
+				}
+				gotInitContainers = append(gotInitContainers, c.Name)
+			}
+			if diff := cmp.Diff(tc.expectedInitContainers, gotInitContainers); diff != "" {
+				t.Errorf("unexpected init containers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCleanupJobFinished(t *testing.T) {
+	testCases := []struct {
+		name              string
+		conditions        []batchv1.JobCondition
+		expectedFinished  bool
+		expectedSucceeded bool
+	}{
+		{
+			name: "Running",
+		},
+		{
+			name: "Completed",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			},
+			expectedFinished:  true,
+			expectedSucceeded: true,
+		},
+		{
+			name: "Failed",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+			},
+			expectedFinished: true,
+		},
+		{
+			name: "Suspended",
+			conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobSuspended, Status: corev1.ConditionTrue},
+				{Type: batchv1.JobComplete, Status: corev1.ConditionFalse},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := &batchv1.Job{Status: batchv1.JobStatus{Conditions: tc.conditions}}
+			finished, succeeded := cleanupJobFinished(job)
+			if finished != tc.expectedFinished || succeeded != tc.expectedSucceeded {
+				t.Errorf("expected finished=%t succeeded=%t, got finished=%t succeeded=%t", tc.expectedFinished, tc.expectedSucceeded, finished, succeeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+// ensureExternalDNSClusterRoleBinding ensures that the externalDNS cluster role binding exists.
+// The cluster role binding grants the operand's cluster role to the given service account.
+// It's needed only for the operand namespaces given in ExternalDNS spec,
+// the service accounts of the default operand namespace are bound by the static manifests.
+func (r *reconciler) ensureExternalDNSClusterRoleBinding(ctx context.Context, serviceAccount *corev1.ServiceAccount, externalDNS *operatorv1beta1.ExternalDNS) (bool, *rbacv1.ClusterRoleBinding, error) {
+	name := types.NamespacedName{Name: controller.ExternalDNSResourceName(externalDNS)}
+
+	desired := desiredExternalDNSClusterRoleBinding(serviceAccount, externalDNS)
+
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return false, nil, fmt.Errorf("failed to set the controller reference for cluster role binding: %w", err)
+	}
+
+	exist, current, err := r.currentExternalDNSClusterRoleBinding(ctx, name)
+	if err != nil {
+		return false, nil, err
+	}
+
+	if !exist {
+		if err := r.createExternalDNSClusterRoleBinding(ctx, desired); err != nil {
+			return false, nil, err
+		}
+		return r.currentExternalDNSClusterRoleBinding(ctx, name)
+	}
+
+	// the role reference is immutable,
+	// only the subjects can be updated
+	if !reflect.DeepEqual(current.Subjects, desired.Subjects) {
+		updated := current.DeepCopy()
+		updated.Subjects = desired.Subjects
+		if err := r.client.Update(ctx, updated); err != nil {
+			return true, current, fmt.Errorf("failed to update externalDNS cluster role binding %s: %w", updated.Name, err)
+		}
+		r.log.Info("updated externalDNS cluster role binding", "name", updated.Name)
+		return r.currentExternalDNSClusterRoleBinding(ctx, name)
+	}
+
+	return true, current, nil
+}
+
+// currentExternalDNSClusterRoleBinding gets the current externalDNS cluster role binding resource.
+func (r *reconciler) currentExternalDNSClusterRoleBinding(ctx context.Context, name types.NamespacedName) (bool, *rbacv1.ClusterRoleBinding, error) {
+	crb := &rbacv1.ClusterRoleBinding{}
+	if err := r.client.Get(ctx, name, crb); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, crb, nil
+}
+
+// desiredExternalDNSClusterRoleBinding returns the desired cluster role binding resource.
+func desiredExternalDNSClusterRoleBinding(serviceAccount *corev1.ServiceAccount, externalDNS *operatorv1beta1.ExternalDNS) *rbacv1.ClusterRoleBinding {
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   controller.ExternalDNSResourceName(externalDNS),
+			Labels: controller.ExternalDNSOperandLabels(),
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     controller.ExternalDNSGlobalResourceName(),
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      serviceAccount.Name,
+				Namespace: serviceAccount.Namespace,
+			},
+		},
+	}
+}
+
+// createExternalDNSClusterRoleBinding creates the given cluster role binding using the reconciler's client.
+func (r *reconciler) createExternalDNSClusterRoleBinding(ctx context.Context, crb *rbacv1.ClusterRoleBinding) error {
+	if err := r.client.Create(ctx, crb); err != nil {
+		return fmt.Errorf("failed to create externalDNS cluster role binding %s: %w", crb.Name, err)
+	}
+
+	r.log.Info("created externalDNS cluster role binding", "name", crb.Name)
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+)
+
+// computeConflictingCondition returns an externalDNS condition which notes
+// whether any other ExternalDNS instance targets the overlapping zones and domains
+// of the same provider. The overlapping instances compete for the same DNS names,
+// the ones which share the TXT owner ID or use the Noop registry may overwrite or delete each other's DNS records
+// and are reported with a distinct reason.
+// The condition is set on all the overlapping instances as the reconciliation
+// of any ExternalDNS triggers the reconciliation of the others.
+func (r *reconciler) computeConflictingCondition(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) metav1.Condition {
+	extDNSList := &operatorv1beta1.ExternalDNSList{}
+	if err := r.client.List(ctx, extDNSList); err != nil {
+		return metav1.Condition{
+			Type:    ExternalDNSConflictingConditionType,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "ConflictUnknown",
+			Message: "Unable to list ExternalDNS instances: " + err.Error(),
+		}
+	}
+
+	conflicting, sharing := []string{}, []string{}
+	for i := range extDNSList.Items {
+		other := &extDNSList.Items[i]
+		// the instance being deleted doesn't publish the records anymore
+		if other.Name == externalDNS.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		if externalDNS.SharesRecordsWith(other) {
+			sharing = append(sharing, other.Name)
+		} else if externalDNS.OverlapsWith(other) {
+			conflicting = append(conflicting, other.Name)
+		}
+	}
+
+	if len(sharing) > 0 {
+		sort.Strings(sharing)
+		return metav1.Condition{
+			Type:    ExternalDNSConflictingConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "SharedRecordsOwnership",
+			Message: fmt.Sprintf("The ExternalDNS instances %s target the same zones and domains of the %s provider with the same TXT owner ID or the Noop registry, the instances may overwrite or delete each other's DNS records", strings.Join(sharing, ", "), externalDNS.Spec.Provider.Type),
+		}
+	}
+
+	if len(conflicting) == 0 {
+		return metav1.Condition{
+			Type:    ExternalDNSConflictingConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "NoOverlappingInstances",
+			Message: "No other ExternalDNS instance targets the same zones and domains",
+		}
+	}
+
+	sort.Strings(conflicting)
+	return metav1.Condition{
+		Type:    ExternalDNSConflictingConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "OverlappingInstances",
+		Message: fmt.Sprintf("The ExternalDNS instances %s target the same zones and domains of the %s provider, the instances may compete for the same DNS names", strings.Join(conflicting, ", "), externalDNS.Spec.Provider.Type),
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestComputeConflictingCondition(t *testing.T) {
+	instance := func(name string, providerType operatorv1beta1.ExternalDNSProviderType, zones []string, domains ...string) *operatorv1beta1.ExternalDNS {
+		extDNS := testExtDNSInstance()
+		extDNS.Name = name
+		extDNS.Spec.Provider.Type = providerType
+		extDNS.Spec.Zones = zones
+		for _, domain := range domains {
+			extDNS.Spec.Domains = append(extDNS.Spec.Domains, operatorv1beta1.ExternalDNSDomain{
+				FilterType: operatorv1beta1.FilterTypeInclude,
+				ExternalDNSDomainUnion: operatorv1beta1.ExternalDNSDomainUnion{
+					MatchType: operatorv1beta1.DomainMatchTypeExact,
+					Name:      ptr.To(domain),
+				},
+			})
+		}
+		return extDNS
+	}
+	withOwnerID := func(extDNS *operatorv1beta1.ExternalDNS, ownerID string) *operatorv1beta1.ExternalDNS {
+		extDNS.Spec.Registry.TXT = &operatorv1beta1.ExternalDNSTXTRegistryOptions{OwnerID: ownerID}
+		return extDNS
+	}
+	withNoopRegistry := func(extDNS *operatorv1beta1.ExternalDNS) *operatorv1beta1.ExternalDNS {
+		extDNS.Spec.Registry.Type = operatorv1beta1.RegistryTypeNoop
+		return extDNS
+	}
+	beingDeleted := func(extDNS *operatorv1beta1.ExternalDNS) *operatorv1beta1.ExternalDNS {
+		extDNS.Finalizers = []string{"test"}
+		extDNS.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		return extDNS
+	}
+
+	testCases := []struct {
+		name              string
+		inputExtDNS       *operatorv1beta1.ExternalDNS
+		existingObjects   []runtime.Object
+		expectedCondition metav1.Condition
+	}{
+		{
+			name:        "Single instance",
+			inputExtDNS: instance(test.Name, operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}),
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSConflictingConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NoOverlappingInstances",
+				Message: "No other ExternalDNS instance targets the same zones and domains",
+			},
+		},
+		{
+			name:        "Same zone and domain",
+			inputExtDNS: instance(test.Name, operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}, "example.com"),
+			existingObjects: []runtime.Object{
+				instance("other-b", operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}, "apps.example.com"),
+				instance("other-a", operatorv1beta1.ProviderTypeAWS, nil, "Example.com."),
+			},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSConflictingConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "OverlappingInstances",
+				Message: "The ExternalDNS instances other-a, other-b target the same zones and domains of the AWS provider, the instances may compete for the same DNS names",
+			},
+		},
+		{
+			name:        "Same zone and TXT owner ID",
+			inputExtDNS: withOwnerID(instance(test.Name, operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}, "example.com"), "shared-owner"),
+			existingObjects: []runtime.Object{
+				withOwnerID(instance("other-a", operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}, "apps.example.com"), "shared-owner"),
+				instance("other-b", operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}, "example.com"),
+			},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSConflictingConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "SharedRecordsOwnership",
+				Message: "The ExternalDNS instances other-a target the same zones and domains of the AWS provider with the same TXT owner ID or the Noop registry, the instances may overwrite or delete each other's DNS records",
+			},
+		},
+		{
+			name:        "Same zone and Noop registry",
+			inputExtDNS: instance(test.Name, operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}),
+			existingObjects: []runtime.Object{
+				withNoopRegistry(instance("other", operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone})),
+			},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSConflictingConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "SharedRecordsOwnership",
+				Message: "The ExternalDNS instances other target the same zones and domains of the AWS provider with the same TXT owner ID or the Noop registry, the instances may overwrite or delete each other's DNS records",
+			},
+		},
+		{
+			name:        "Same TXT owner ID and different zones",
+			inputExtDNS: withOwnerID(instance(test.Name, operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}), "shared-owner"),
+			existingObjects: []runtime.Object{
+				withOwnerID(instance("other", operatorv1beta1.ProviderTypeAWS, []string{test.PrivateZone}), "shared-owner"),
+			},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSConflictingConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NoOverlappingInstances",
+				Message: "No other ExternalDNS instance targets the same zones and domains",
+			},
+		},
+		{
+			name:        "Different zones",
+			inputExtDNS: instance(test.Name, operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}),
+			existingObjects: []runtime.Object{
+				instance("other", operatorv1beta1.ProviderTypeAWS, []string{test.PrivateZone}),
+			},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSConflictingConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NoOverlappingInstances",
+				Message: "No other ExternalDNS instance targets the same zones and domains",
+			},
+		},
+		{
+			name:        "Different domains",
+			inputExtDNS: instance(test.Name, operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}, "foo.example.com"),
+			existingObjects: []runtime.Object{
+				instance("other", operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}, "bar.example.com"),
+			},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSConflictingConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NoOverlappingInstances",
+				Message: "No other ExternalDNS instance targets the same zones and domains",
+			},
+		},
+		{
+			name:        "Different providers",
+			inputExtDNS: instance(test.Name, operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}),
+			existingObjects: []runtime.Object{
+				instance("other", operatorv1beta1.ProviderTypeGCP, []string{test.PublicZone}),
+			},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSConflictingConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NoOverlappingInstances",
+				Message: "No other ExternalDNS instance targets the same zones and domains",
+			},
+		},
+		{
+			name:        "Overlapping instance being deleted",
+			inputExtDNS: instance(test.Name, operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone}),
+			existingObjects: []runtime.Object{
+				beingDeleted(instance("other", operatorv1beta1.ProviderTypeAWS, []string{test.PublicZone})),
+			},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSConflictingConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NoOverlappingInstances",
+				Message: "No other ExternalDNS instance targets the same zones and domains",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &reconciler{
+				client: fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(append(tc.existingObjects, tc.inputExtDNS)...).Build(),
+				scheme: test.Scheme,
+				config: testConfig(),
+				log:    zap.New(zap.UseDevMode(true)),
+			}
+
+			cond := r.computeConflictingCondition(context.TODO(), tc.inputExtDNS)
+			if diff := cmp.Diff(tc.expectedCondition, cond); diff != "" {
+				t.Errorf("unexpected condition (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
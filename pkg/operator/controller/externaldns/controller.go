@@ -19,14 +19,19 @@ package externaldnscontroller
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -44,18 +49,32 @@ import (
 	operatorutils "github.com/openshift/external-dns-operator/pkg/utils"
 )
 
+const (
+	// statusRefreshPeriod is the period after which the status of the successfully reconciled ExternalDNS is refreshed.
+	statusRefreshPeriod = 5 * time.Minute
+)
+
 // Config holds all the things necessary for the controller to run.
 type Config struct {
-	// Namespace is the namespace that ExternalDNS should be deployed in.
+	// Namespace is the namespace that ExternalDNS should be deployed in
+	// unless the ExternalDNS instance specifies its own operand namespace.
 	Namespace string
+	// AllowedNamespaces are the namespaces which ExternalDNS instances can specify as their operand namespace.
+	AllowedNamespaces []string
 	// Image is the ExternalDNS image to use.
 	Image string
+	// MetricsProxyImage is the kube-rbac-proxy image to use
+	// for the sidecars which expose the metrics of ExternalDNS.
+	MetricsProxyImage string
 	// OperatorNamespace is the namespace in which this operator is deployed.
 	OperatorNamespace string
 	// IsOpenShift is the flag which instructs the operator that it runs in OpenShift.
 	IsOpenShift bool
 	// PlatformStatus is the details about the underlying platform.
 	PlatformStatus *configv1.PlatformStatus
+	// NativeSidecars is the flag which instructs the operator that the cluster supports the native sidecars,
+	// the cleanup job of the webhook provider relies on them.
+	NativeSidecars bool
 	// InjectTrustedCA is the flag which instructs the operator to inject the trusted CA into ExternalDNS containers.
 	InjectTrustedCA bool
 	// RequeuePeriod is the period to wait after a failed reconciliation.
@@ -68,6 +87,15 @@ type reconciler struct {
 	client client.Client
 	scheme *runtime.Scheme
 	log    logr.Logger
+	// podLogs reads the logs of the operand containers,
+	// the provider authentication failures are not detected in the logs if nil.
+	podLogs podLogsFunc
+	// scrapeMetrics reads the metrics of the operand containers,
+	// the zone statuses don't report the synchronization details if nil.
+	scrapeMetrics scrapeMetricsFunc
+	// recorder emits the events about the ExternalDNS instances,
+	// the result of the cleanup of the records is not reported if nil.
+	recorder record.EventRecorder
 }
 
 // New creates the externaldns controller from mgr and cfg. The controller will be pre-configured
@@ -78,11 +106,23 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 	operatorScheme := mgr.GetScheme()
 	operatorRESTMapper := mgr.GetRESTMapper()
 
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+
 	r := &reconciler{
-		config: cfg,
-		client: mgr.GetClient(),
-		scheme: mgr.GetScheme(),
-		log:    log,
+		config:   cfg,
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		log:      log,
+		podLogs:  newCachedPodLogsFunc(newPodLogsFunc(clientset), podLogsCachePeriod),
+		recorder: mgr.GetEventRecorderFor(controlleroperator.ControllerName),
+	}
+	// the serving certificate of kube-rbac-proxy can only be verified against the service CA of OpenShift,
+	// the token of the operator must not be sent to an unverified endpoint
+	if cfg.IsOpenShift {
+		r.scrapeMetrics = newScrapeMetricsFunc(mgr.GetConfig())
 	}
 
 	c, err := controller.New(controlleroperator.ControllerName, mgr, controller.Options{Reconciler: r})
@@ -94,6 +134,31 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 		return nil, err
 	}
 
+	// enqueue the other ExternalDNS instances if the spec of any instance changed
+	// to update the condition about the overlapping instances on all of them
+	otherExtDNSInstances := func(ctx context.Context, o client.Object) []reconcile.Request {
+		externalDNSList := &operatorv1beta1.ExternalDNSList{}
+		requests := []reconcile.Request{}
+		if err := mgr.GetCache().List(ctx, externalDNSList); err != nil {
+			log.Error(err, "failed to list externalDNS for overlapping instances", "name", o.GetName())
+			return requests
+		}
+		for _, ed := range externalDNSList.Items {
+			if ed.Name == o.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: ed.Name,
+				},
+			})
+		}
+		return requests
+	}
+	if err := c.Watch(source.Kind[client.Object](operatorCache, &operatorv1beta1.ExternalDNS{}, handler.EnqueueRequestsFromMapFunc(otherExtDNSInstances), predicate.GenerationChangedPredicate{})); err != nil {
+		return nil, err
+	}
+
 	if err := c.Watch(source.Kind[client.Object](operatorCache, &appsv1.Deployment{}, handler.EnqueueRequestForOwner(operatorScheme, operatorRESTMapper, &operatorv1beta1.ExternalDNS{}, handler.OnlyControllerOwner()))); err != nil {
 		return nil, err
 	}
@@ -102,6 +167,19 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 		return nil, err
 	}
 
+	if err := c.Watch(source.Kind[client.Object](operatorCache, &corev1.Service{}, handler.EnqueueRequestForOwner(operatorScheme, operatorRESTMapper, &operatorv1beta1.ExternalDNS{}, handler.OnlyControllerOwner()))); err != nil {
+		return nil, err
+	}
+
+	// the completion of the cleanup job unblocks the deletion of ExternalDNS instance
+	if err := c.Watch(source.Kind[client.Object](operatorCache, &batchv1.Job{}, handler.EnqueueRequestForOwner(operatorScheme, operatorRESTMapper, &operatorv1beta1.ExternalDNS{}, handler.OnlyControllerOwner()))); err != nil {
+		return nil, err
+	}
+
+	if err := c.Watch(source.Kind[client.Object](operatorCache, &rbacv1.ClusterRoleBinding{}, handler.EnqueueRequestForOwner(operatorScheme, operatorRESTMapper, &operatorv1beta1.ExternalDNS{}, handler.OnlyControllerOwner()), predicate.NewPredicateFuncs(ctrlutils.HasLabel(controlleroperator.ExternalDNSOperandLabel)))); err != nil {
+		return nil, err
+	}
+
 	// secret replicated by the credentials controller
 	// needs to trigger the reconciliation of the corresponding ExternalDNS
 	// because of the annotation with the secret's hash in the operand deployment
@@ -133,8 +211,9 @@ func New(mgr manager.Manager, cfg Config) (controller.Controller, error) {
 	if err := c.Watch(
 		source.Kind[client.Object](operatorCache, &corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(allExtDNSInstances),
-			// only the target trusted CA configmap
-			predicate.NewPredicateFuncs(ctrlutils.InNamespace(cfg.Namespace)),
+			// only the target trusted CA configmaps:
+			// the one from the default operand namespace and the ones copied to the operand namespaces given in ExternalDNS spec
+			predicate.Or(predicate.NewPredicateFuncs(ctrlutils.InNamespace(cfg.Namespace)), predicate.NewPredicateFuncs(ctrlutils.HasLabel(controlleroperator.ExternalDNSOperandLabel))),
 			predicate.NewPredicateFuncs(ctrlutils.HasName(controlleroperator.ExternalDNSDestTrustedCAConfigMapName(cfg.Namespace).Name)),
 		)); err != nil {
 		return nil, err
@@ -158,6 +237,14 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return reconcile.Result{}, fmt.Errorf("failed to get externalDNS %s: %w", req, err)
 	}
 
+	if externalDNS.DeletionTimestamp != nil {
+		return r.finalizeExternalDNS(ctx, externalDNS)
+	}
+
+	if err := r.ensureExternalDNSFinalizer(ctx, externalDNS); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS finalizer: %w", err)
+	}
+
 	// request credentials from CCO only if all of the following is true:
 	//  - underlying platform is OpenShift
 	//  - DNS provider is supported by CCO
@@ -168,23 +255,46 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		if _, _, err := r.ensureExternalCredentialsRequest(ctx, externalDNS); err != nil {
 			return reconcile.Result{}, fmt.Errorf("failed to ensure credentials request for externalDNS: %w", err)
 		}
+		if err := r.deleteLegacyExternalDNSCredentialsRequest(ctx, externalDNS); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to delete legacy credentials request for externalDNS: %w", err)
+		}
 	}
 
-	haveServiceAccount, sa, err := r.ensureExternalDNSServiceAccount(ctx, r.config.Namespace, externalDNS)
+	conflictingCond := r.computeConflictingCondition(ctx, externalDNS)
+
+	operandNamespace := controlleroperator.ExternalDNSOperandNamespace(externalDNS, r.config.Namespace)
+	// the operator is granted the permissions only in the allowed namespaces,
+	// the webhook may be disabled
+	if operandNamespace != r.config.Namespace && !slices.Contains(r.config.AllowedNamespaces, operandNamespace) {
+		return reconcile.Result{}, fmt.Errorf("operand namespace %q is not allowed", operandNamespace)
+	}
+
+	haveServiceAccount, sa, err := r.ensureExternalDNSServiceAccount(ctx, operandNamespace, externalDNS)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS service account: %w", err)
 	} else if !haveServiceAccount {
 		return reconcile.Result{}, fmt.Errorf("failed to get externalDNS service account: %w", err)
 	}
 
-	credSecretNsName := controlleroperator.ExternalDNSDestCredentialsSecretName(r.config.Namespace, externalDNS.Name)
+	// the service accounts from the default operand namespace
+	// are bound to the operand's cluster role by the static manifests
+	if operandNamespace != r.config.Namespace {
+		if _, _, err := r.ensureExternalDNSClusterRoleBinding(ctx, sa, externalDNS); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS cluster role binding: %w", err)
+		}
+	}
+
+	credSecretNsName := controlleroperator.ExternalDNSDestCredentialsSecretName(operandNamespace, externalDNS.Name)
 	credSecretExists, credSecret, err := r.currentExternalDNSSecret(ctx, credSecretNsName)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to get the target credentials secret: %w", err)
 	}
-	if !credSecretExists {
+	if !credSecretExists && workloadIdentityWithoutSecret(externalDNS) {
+		// the projected service account token replaces the credentials secret
+		credSecret = &corev1.Secret{}
+	} else if !credSecretExists {
 		// show that the secret is not there yet
-		if err := r.updateExternalDNSStatus(ctx, externalDNS, nil, false); err != nil {
+		if err := r.updateExternalDNSStatus(ctx, externalDNS, nil, false, conflictingCond); err != nil {
 			reqLogger.Error(err, "failed to update externalDNS custom resource")
 		}
 		// credentials secret was not synced yet or doesn't exist at all,
@@ -194,7 +304,7 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 
 	var trustCAConfigMap *corev1.ConfigMap
 	if r.config.InjectTrustedCA {
-		configMapNsName := controlleroperator.ExternalDNSDestTrustedCAConfigMapName(r.config.Namespace)
+		configMapNsName := controlleroperator.ExternalDNSDestTrustedCAConfigMapName(operandNamespace)
 		configMapExists, configMap, err := r.currentExternalDNSTrustedCAConfigMap(ctx, configMapNsName)
 		if err != nil {
 			return reconcile.Result{}, fmt.Errorf("failed to get the target CA configmap: %w", err)
@@ -207,14 +317,37 @@ func (r *reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		trustCAConfigMap = configMap
 	}
 
-	_, currentDeployment, err := r.ensureExternalDNSDeployment(ctx, r.config.Namespace, r.config.Image, sa, credSecret, trustCAConfigMap, externalDNS)
+	_, currentDeployment, err := r.ensureExternalDNSDeployment(ctx, operandNamespace, r.config.Image, sa, credSecret, trustCAConfigMap, externalDNS)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS deployment: %w", err)
 	}
 
-	if err := r.updateExternalDNSStatus(ctx, externalDNS, currentDeployment, true); err != nil {
+	if err := r.ensureExternalDNSMetrics(ctx, operandNamespace, externalDNS); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS metrics: %w", err)
+	}
+
+	dryRunCond, err := r.ensureExternalDNSDryRunPlan(ctx, operandNamespace, externalDNS, currentDeployment)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS dry run plan: %w", err)
+	}
+
+	ownerMigrationCond := r.computeOwnerMigrationCondition(ctx, externalDNS, currentDeployment)
+
+	if err := r.updateExternalDNSStatus(ctx, externalDNS, currentDeployment, credSecretExists, dryRunCond, ownerMigrationCond, conflictingCond); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to update externalDNS custom resource %s: %w", externalDNS.Name, err)
 	}
 
-	return reconcile.Result{}, nil
+	// the provider authentication failures logged by the operand
+	// don't trigger any event, the status needs to be refreshed periodically
+	return reconcile.Result{RequeueAfter: statusRefreshPeriod}, nil
+}
+
+// workloadIdentityWithoutSecret returns true if the provider of the given externalDNS
+// authenticates with the workload identity and no credentials secret is referenced.
+// The secret is optional in this case, it can still hold the keys not related to the provider.
+// The providers configured by the rendered config file always need the secret.
+func workloadIdentityWithoutSecret(externalDNS *operatorv1beta1.ExternalDNS) bool {
+	return operatorutils.WorkloadIdentityProvider(externalDNS) &&
+		!operatorutils.WorkloadIdentityConfigProvider(externalDNS) &&
+		controlleroperator.ExternalDNSCredentialsSecretNameFromProvider(externalDNS) == ""
 }
@@ -19,7 +19,6 @@ package externaldnscontroller
 import (
 	"context"
 	"reflect"
-	"strings"
 	"testing"
 	"time"
 
@@ -27,7 +26,9 @@ import (
 	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -40,6 +41,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
 	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
 )
 
@@ -49,6 +51,9 @@ const (
 	externalDNSResource        = "externaldns"
 	serviceAccountResource     = "serviceaccount"
 	credentialsrequestResource = "credentialsrequest"
+	clusterRoleBindingResource = "clusterrolebinding"
+	jobResource                = "job"
+	testTenantNamespace        = "tenant-a"
 )
 
 func TestReconcile(t *testing.T) {
@@ -57,6 +62,8 @@ func TestReconcile(t *testing.T) {
 		&corev1.NamespaceList{},
 		&appsv1.DeploymentList{},
 		&corev1.ServiceAccountList{},
+		&rbacv1.ClusterRoleBindingList{},
+		&batchv1.JobList{},
 		&operatorv1beta1.ExternalDNSList{},
 	}
 	eventWaitTimeout := time.Duration(1 * time.Second)
@@ -75,7 +82,7 @@ func TestReconcile(t *testing.T) {
 			existingObjects: []runtime.Object{testExtDNSInstance(), testSecret()},
 			inputConfig:     testConfig(),
 			inputRequest:    testRequest(),
-			expectedResult:  reconcile.Result{},
+			expectedResult:  reconcile.Result{RequeueAfter: statusRefreshPeriod},
 			expectedEvents: []test.Event{
 				{
 					EventType: watch.Added,
@@ -107,13 +114,13 @@ func TestReconcile(t *testing.T) {
 			existingObjects: []runtime.Object{testExtDNSInstanceNoSecret(), testSecret()},
 			inputConfig:     testConfigOpenShift(),
 			inputRequest:    testRequest(),
-			expectedResult:  reconcile.Result{},
+			expectedResult:  reconcile.Result{RequeueAfter: statusRefreshPeriod},
 			expectedEvents: []test.Event{
 				{
 					EventType: watch.Added,
 					ObjType:   credentialsrequestResource,
 					NamespacedName: types.NamespacedName{
-						Name: "externaldns-credentials-request-" + strings.ToLower(string(testExtDNSInstance().Spec.Provider.Type)),
+						Name: controller.ExternalDNSResourceName(testExtDNSInstance()),
 					},
 				},
 				{
@@ -146,7 +153,86 @@ func TestReconcile(t *testing.T) {
 			existingObjects: []runtime.Object{testExtDNSInstance(), testSecret()},
 			inputConfig:     testConfigOpenShift(),
 			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{RequeueAfter: statusRefreshPeriod},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   deploymentResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Added,
+					ObjType:   serviceAccountResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
+		{
+			name:            "Bootstrap with operand namespace",
+			existingObjects: []runtime.Object{testExtDNSInstanceWithOperandNamespace(), testSecretInNamespace(testTenantNamespace)},
+			inputConfig:     testConfigAllowedNamespaces(testTenantNamespace),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{RequeueAfter: statusRefreshPeriod},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   deploymentResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: testTenantNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Added,
+					ObjType:   serviceAccountResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: testTenantNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Added,
+					ObjType:   clusterRoleBindingResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
+		{
+			name:            "Bootstrap with not allowed operand namespace",
+			existingObjects: []runtime.Object{testExtDNSInstanceWithOperandNamespace(), testSecretInNamespace(testTenantNamespace)},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
 			expectedResult:  reconcile.Result{},
+			errExpected:     true,
+		},
+		{
+			name:            "Bootstrap with cleanup deletion policy",
+			existingObjects: []runtime.Object{testExtDNSInstanceWithCleanup(), testSecret()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{RequeueAfter: statusRefreshPeriod},
 			expectedEvents: []test.Event{
 				{
 					EventType: watch.Added,
@@ -173,6 +259,110 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:            "Deleting ExternalDNS with running deployment",
+			existingObjects: []runtime.Object{testExtDNSInstanceBeingDeleted(), testSecret(), testServiceAccount(), testOperandDeployment()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Deleted,
+					ObjType:   deploymentResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName,
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
+		{
+			name:            "Deleting ExternalDNS starts cleanup job",
+			existingObjects: []runtime.Object{testExtDNSInstanceBeingDeleted(), testSecret(), testServiceAccount()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   jobResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName + "-cleanup",
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
+		{
+			name:            "Deleting ExternalDNS with web identity starts cleanup job",
+			existingObjects: []runtime.Object{testExtDNSInstanceWebIdentityBeingDeleted(), testServiceAccount()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Added,
+					ObjType:   jobResource,
+					NamespacedName: types.NamespacedName{
+						Namespace: test.OperandNamespace,
+						Name:      test.OperandName + "-cleanup",
+					},
+				},
+				{
+					EventType: watch.Modified,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
+		{
+			name:            "Deleting ExternalDNS with completed cleanup job",
+			existingObjects: []runtime.Object{testExtDNSInstanceBeingDeleted(), testSecret(), testServiceAccount(), testCleanupJob(batchv1.JobComplete)},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Deleted,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
+		{
+			name:            "Deleting ExternalDNS without operand resources",
+			existingObjects: []runtime.Object{testExtDNSInstanceBeingDeleted()},
+			inputConfig:     testConfig(),
+			inputRequest:    testRequest(),
+			expectedResult:  reconcile.Result{},
+			expectedEvents: []test.Event{
+				{
+					EventType: watch.Deleted,
+					ObjType:   externalDNSResource,
+					NamespacedName: types.NamespacedName{
+						Name: test.Name,
+					},
+				},
+			},
+		},
 		{
 			name:            "Deleted ExternalDNS",
 			existingObjects: []runtime.Object{},
@@ -236,6 +426,14 @@ func testConfig() Config {
 	}
 }
 
+func testConfigAllowedNamespaces(namespaces ...string) Config {
+	return Config{
+		Namespace:         test.OperandNamespace,
+		AllowedNamespaces: namespaces,
+		Image:             test.OperandImage,
+	}
+}
+
 func testConfigOpenShift() Config {
 	return Config{
 		Namespace:   test.OperandNamespace,
@@ -290,11 +488,80 @@ func testExtDNSInstance() *operatorv1beta1.ExternalDNS {
 	return extDNS
 }
 
+func testExtDNSInstanceWithOperandNamespace() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstance()
+	extDNS.Spec.OperandNamespace = testTenantNamespace
+	return extDNS
+}
+
+func testExtDNSInstanceWithCleanup() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstance()
+	extDNS.Spec.DeletionPolicy = operatorv1beta1.DeletionPolicyCleanupRecords
+	return extDNS
+}
+
+func testExtDNSInstanceBeingDeleted() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstanceWithCleanup()
+	extDNS.Finalizers = []string{controller.ExternalDNSCleanupFinalizer}
+	extDNS.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	return extDNS
+}
+
+func testExtDNSInstanceWebIdentityBeingDeleted() *operatorv1beta1.ExternalDNS {
+	extDNS := testExtDNSInstanceBeingDeleted()
+	extDNS.Spec.Provider.AWS = &operatorv1beta1.ExternalDNSAWSProviderOptions{
+		CredentialsMode: operatorv1beta1.AWSCredentialsModeWebIdentity,
+		WebIdentity: &operatorv1beta1.ExternalDNSAWSWebIdentityOptions{
+			RoleARN: "arn:aws:iam::123456789012:role/external-dns",
+		},
+	}
+	return extDNS
+}
+
+func testServiceAccount() *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      test.OperandName,
+			Namespace: test.OperandNamespace,
+		},
+	}
+}
+
+func testOperandDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      test.OperandName,
+			Namespace: test.OperandNamespace,
+		},
+	}
+}
+
+func testCleanupJob(condType batchv1.JobConditionType) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      test.OperandName + "-cleanup",
+			Namespace: test.OperandNamespace,
+		},
+		Status: batchv1.JobStatus{
+			Conditions: []batchv1.JobCondition{
+				{
+					Type:   condType,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+}
+
 func testSecret() *corev1.Secret {
+	return testSecretInNamespace(test.OperandNamespace)
+}
+
+func testSecretInNamespace(namespace string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      test.OperandSecretName,
-			Namespace: test.OperandNamespace,
+			Namespace: namespace,
 		},
 	}
 }
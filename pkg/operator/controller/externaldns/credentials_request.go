@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
 	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
@@ -28,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
 	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
@@ -35,6 +37,9 @@ import (
 )
 
 // ensureExternalCredentialsRequest ensures that the externalDNS credential request exists.
+// Each ExternalDNS instance has its own credentials request.
+// Only the AWS request is limited to the zones of the instance,
+// see createProviderConfig for the limits of the other providers.
 // Returns a boolean if the credential request exists, its current state if it exists
 // and an error if it cannot be created or updated.
 func (r *reconciler) ensureExternalCredentialsRequest(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) (bool, *cco.CredentialsRequest, error) {
@@ -47,7 +52,7 @@ func (r *reconciler) ensureExternalCredentialsRequest(ctx context.Context, exter
 	}
 
 	secretName := types.NamespacedName{
-		Name:      controller.SecretFromCloudCredentialsOperator,
+		Name:      controller.ExternalDNSCloudCredentialsSecretName(externalDNS),
 		Namespace: r.config.OperatorNamespace,
 	}
 	desired, err := desiredCredentialsRequest(name, secretName, externalDNS, r.config.PlatformStatus)
@@ -55,6 +60,10 @@ func (r *reconciler) ensureExternalCredentialsRequest(ctx context.Context, exter
 		return false, nil, err
 	}
 
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return false, nil, fmt.Errorf("failed to set the controller reference for credentials request: %w", err)
+	}
+
 	if !exists {
 		if err := r.createExternalDNSCredentialsRequest(ctx, desired); err != nil {
 			return false, nil, err
@@ -71,6 +80,63 @@ func (r *reconciler) ensureExternalCredentialsRequest(ctx context.Context, exter
 	return true, current, nil
 }
 
+// deleteLegacyExternalDNSCredentialsRequest deletes the credentials request which used to be shared
+// by all the ExternalDNS instances of the same provider type. Its credentials are not limited to any zone.
+// The cloud credentials operator deletes the shared secret together with the request,
+// the request is kept until the secrets of all the instances which may still use it are provisioned.
+func (r *reconciler) deleteLegacyExternalDNSCredentialsRequest(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) error {
+	name := controller.ExternalDNSLegacyCredentialsRequestName(externalDNS)
+	exists, current, err := r.currentExternalDNSCredentialsRequest(ctx, name)
+	if err != nil {
+		return err
+	} else if !exists {
+		return nil
+	}
+
+	provisioned, err := r.cloudCredentialsSecretsProvisioned(ctx, externalDNS.Spec.Provider.Type)
+	if err != nil {
+		return err
+	} else if !provisioned {
+		r.log.Info("legacy externalDNS credentials request is still in use", "name", name.Name, "namespace", name.Namespace)
+		return nil
+	}
+
+	if err := r.client.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete legacy externalDNS credentials request %s: %w", name, err)
+	}
+	r.log.Info("deleted legacy externalDNS credentials request", "name", name.Name, "namespace", name.Namespace)
+	return nil
+}
+
+// cloudCredentialsSecretsProvisioned returns true if the cloud credentials secrets of all the externalDNS instances
+// of the given provider type which request their credentials from the cloud credentials operator exist.
+func (r *reconciler) cloudCredentialsSecretsProvisioned(ctx context.Context, providerType operatorv1beta1.ExternalDNSProviderType) (bool, error) {
+	externalDNSList := &operatorv1beta1.ExternalDNSList{}
+	if err := r.client.List(ctx, externalDNSList); err != nil {
+		return false, fmt.Errorf("failed to list externalDNS instances: %w", err)
+	}
+
+	for i := range externalDNSList.Items {
+		ed := &externalDNSList.Items[i]
+		// the instances being deleted don't get a credentials request anymore
+		if ed.Spec.Provider.Type != providerType || ed.DeletionTimestamp != nil ||
+			!utils.ManagedCredentialsProvider(ed) || controller.ExternalDNSCredentialsSecretNameFromProvider(ed) != "" {
+			continue
+		}
+		secretName := types.NamespacedName{
+			Name:      controller.ExternalDNSCloudCredentialsSecretName(ed),
+			Namespace: r.config.OperatorNamespace,
+		}
+		if err := r.client.Get(ctx, secretName, &corev1.Secret{}); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get externalDNS cloud credentials secret %s: %w", secretName, err)
+		}
+	}
+	return true, nil
+}
+
 // currentExternalDNSCredentialsRequest returns true if credentials request exists.
 func (r *reconciler) currentExternalDNSCredentialsRequest(ctx context.Context, name types.NamespacedName) (bool, *cco.CredentialsRequest, error) {
 	cr := &cco.CredentialsRequest{}
@@ -222,6 +288,11 @@ func externalDNSCredentialsRequestChanged(current, desired, updated *cco.Credent
 	return changed, nil
 }
 
+// createProviderConfig returns the provider spec which grants the least privileges needed
+// to manage the records of the zones of the given externalDNS.
+// The AWS statements are limited to the hosted zones of the instance.
+// The Azure and GCP specs of the credentials request only take the names of the roles:
+// the credentials allow the changes in all the zones of the resource group or the project.
 func createProviderConfig(externalDNS *operatorv1beta1.ExternalDNS, platformStatus *configv1.PlatformStatus, codec *cco.ProviderCodec) (*runtime.RawExtension, error) {
 	switch externalDNS.Spec.Provider.Type {
 	case operatorv1beta1.ProviderTypeAWS:
@@ -234,27 +305,21 @@ func createProviderConfig(externalDNS *operatorv1beta1.ExternalDNS, platformStat
 				TypeMeta: metav1.TypeMeta{
 					Kind: "AWSProviderSpec",
 				},
-				StatementEntries: []cco.StatementEntry{
-					{
-						Effect: "Allow",
-						Action: []string{
-							"route53:ChangeResourceRecordSets",
-						},
-						Resource: arnPrefix(region) + ":route53:::hostedzone/*",
-					},
-					{
+				StatementEntries: append(awsHostedZoneStatements(region, externalDNS.Spec.Zones),
+					cco.StatementEntry{
 						Effect: "Allow",
 						Action: []string{
 							"route53:ListHostedZones",
-							"route53:ListResourceRecordSets",
 							"tag:GetResources",
 							"sts:AssumeRole",
 						},
 						Resource: "*",
 					},
-				},
+				),
 			})
 	case operatorv1beta1.ProviderTypeGCP:
+		// the predefined roles of the credentials request cannot be conditioned on the managed zones,
+		// the zones are limited by the zone filter of the operand only
 		return codec.EncodeProviderSpec(
 			&cco.GCPProviderSpec{
 				TypeMeta: metav1.TypeMeta{
@@ -266,19 +331,72 @@ func createProviderConfig(externalDNS *operatorv1beta1.ExternalDNS, platformStat
 			})
 
 	case operatorv1beta1.ProviderTypeAzure:
+		// the role bindings of the credentials request cannot be scoped to the zone resources,
+		// the DNS zone roles are the narrowest ones which are available,
+		// the zones are limited by the zone filter of the operand only
 		return codec.EncodeProviderSpec(
 			&cco.AzureProviderSpec{
 				TypeMeta: metav1.TypeMeta{
 					Kind: "AzureProviderSpec",
 				},
-				RoleBindings: []cco.RoleBinding{
-					{Role: "Contributor"},
-				},
+				RoleBindings: azureDNSZoneRoleBindings(externalDNS.Spec.Zones),
 			})
 	}
 	return nil, nil
 }
 
+// awsHostedZoneStatements returns the statements which allow the changes of the records in the given hosted zones.
+// All the hosted zones are allowed if no zone is given.
+func awsHostedZoneStatements(region string, zones []string) []cco.StatementEntry {
+	actions := []string{
+		"route53:ChangeResourceRecordSets",
+		"route53:ListResourceRecordSets",
+	}
+	if len(zones) == 0 {
+		return []cco.StatementEntry{
+			{
+				Effect:   "Allow",
+				Action:   actions,
+				Resource: arnPrefix(region) + ":route53:::hostedzone/*",
+			},
+		}
+	}
+
+	statements := []cco.StatementEntry{}
+	for _, zone := range zones {
+		statements = append(statements, cco.StatementEntry{
+			Effect:   "Allow",
+			Action:   actions,
+			Resource: arnPrefix(region) + ":route53:::hostedzone/" + strings.TrimPrefix(zone, "/hostedzone/"),
+		})
+	}
+	return statements
+}
+
+// azureDNSZoneRoleBindings returns the role bindings which allow the changes of the records
+// in the public and/or private DNS zones depending on the given zones.
+// Both the public and private DNS zones are allowed if no zone is given.
+func azureDNSZoneRoleBindings(zones []string) []cco.RoleBinding {
+	// the instance without zones publishes to the public and private DNS zones
+	public, private := len(zones) == 0, len(zones) == 0
+	for _, zone := range zones {
+		if strings.Contains(strings.ToLower(zone), azurePrivateDNSZonesResourceSubStr) {
+			private = true
+		} else {
+			public = true
+		}
+	}
+
+	bindings := []cco.RoleBinding{}
+	if public {
+		bindings = append(bindings, cco.RoleBinding{Role: "DNS Zone Contributor"})
+	}
+	if private {
+		bindings = append(bindings, cco.RoleBinding{Role: "Private DNS Zone Contributor"})
+	}
+	return bindings
+}
+
 func arnPrefix(region string) string {
 	if utils.IsUSGovAWSRegion(region) {
 		return "arn:aws-us-gov"
@@ -10,12 +10,15 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
 	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
 )
 
@@ -32,31 +35,31 @@ func TestEnsureCredentialsRequest(t *testing.T) {
 			name:                      "Create credentials request from scratch in AWS",
 			existingObjects:           []runtime.Object{},
 			inputExtDNS:               test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().WithZones("public-zone").Build(),
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
 		},
 		{
 			name:                      "Update drifted credentials request in AWS. Provider spec",
-			existingObjects:           []runtime.Object{newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(undesiredAWSProviderSpec).build()},
+			existingObjects:           []runtime.Object{newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(undesiredAWSProviderSpec).build()},
 			inputExtDNS:               test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().WithZones("public-zone").Build(),
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
 		},
 		{
 			name:                      "Update drifted credentials request in AWS. Secret name",
-			existingObjects:           []runtime.Object{newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build()},
+			existingObjects:           []runtime.Object{newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build()},
 			inputExtDNS:               test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().WithZones("public-zone").Build(),
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
 		},
 		{
 			name:                      "Update drifted credentials request in AWS. Secret namespace",
-			existingObjects:           []runtime.Object{newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "wrong-ns").withProviderSpec(desiredAWSProviderSpec).build()},
+			existingObjects:           []runtime.Object{newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "wrong-ns").withProviderSpec(desiredAWSProviderSpec).build()},
 			inputExtDNS:               test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().WithZones("public-zone").Build(),
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
 		},
 		{
 			name:                      "Update drifted credentials request in AWS. Service accounts",
-			existingObjects:           []runtime.Object{newCredentialsRequest("externaldns-credentials-request-aws").withSAs("wrong-sa").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build()},
+			existingObjects:           []runtime.Object{newCredentialsRequest("external-dns-test").withOwner().withSAs("wrong-sa").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build()},
 			inputExtDNS:               test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().WithZones("public-zone").Build(),
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAWSProviderSpec).build(),
 		},
 		{
 			name:            "Create credentials request from scratch in AWS Gov",
@@ -68,31 +71,55 @@ func TestEnsureCredentialsRequest(t *testing.T) {
 					Region: "us-gov-west-1",
 				},
 			},
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpecGovARN).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAWSProviderSpecGovARN).build(),
+		},
+		{
+			name:                      "Create credentials request from scratch in AWS with multiple zones",
+			existingObjects:           []runtime.Object{},
+			inputExtDNS:               test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().WithZones("public-zone", "/hostedzone/private-zone").Build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAWSProviderSpecMultipleZones).build(),
+		},
+		{
+			name:                      "Create credentials request from scratch in AWS without zones",
+			existingObjects:           []runtime.Object{},
+			inputExtDNS:               test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().Build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAWSProviderSpecAllZones).build(),
 		},
 		{
 			name:                      "Create credentials request from scratch in Azure",
 			existingObjects:           []runtime.Object{},
 			inputExtDNS:               test.NewExternalDNS(test.Name).WithAzure().WithRouteSource().WithZones("public-zone").Build(),
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-azure").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAzureProviderSpec).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAzureProviderSpec).build(),
 		},
 		{
 			name:                      "Update drifted credentials request in Azure. Provider spec",
-			existingObjects:           []runtime.Object{newCredentialsRequest("externaldns-credentials-request-azure").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(undesiredAzureProviderSpec).build()},
+			existingObjects:           []runtime.Object{newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(undesiredAzureProviderSpec).build()},
 			inputExtDNS:               test.NewExternalDNS(test.Name).WithAzure().WithRouteSource().WithZones("public-zone").Build(),
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-azure").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAzureProviderSpec).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAzureProviderSpec).build(),
+		},
+		{
+			name:                      "Create credentials request from scratch in Azure with private zone",
+			existingObjects:           []runtime.Object{},
+			inputExtDNS:               test.NewExternalDNS(test.Name).WithAzure().WithRouteSource().WithZones("/subscriptions/xxxx/resourceGroups/test-rg/providers/Microsoft.Network/privateDnsZones/test.example.com").Build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAzureProviderSpecPrivateZone).build(),
+		},
+		{
+			name:                      "Create credentials request from scratch in Azure without zones",
+			existingObjects:           []runtime.Object{},
+			inputExtDNS:               test.NewExternalDNS(test.Name).WithAzure().WithRouteSource().Build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredAzureProviderSpecAllZones).build(),
 		},
 		{
 			name:                      "Create credentials request from scratch in GCP",
 			existingObjects:           []runtime.Object{},
 			inputExtDNS:               test.NewExternalDNS(test.Name).WithGCP().WithRouteSource().WithZones("public-zone").Build(),
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-gcp").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredGCPProviderSpec).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredGCPProviderSpec).build(),
 		},
 		{
 			name:                      "Update drifted credentials request in GCP. Provider spec",
-			existingObjects:           []runtime.Object{newCredentialsRequest("externaldns-credentials-request-gcp").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(undesiredGCPProviderSpec).build()},
+			existingObjects:           []runtime.Object{newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(undesiredGCPProviderSpec).build()},
 			inputExtDNS:               test.NewExternalDNS(test.Name).WithGCP().WithRouteSource().WithZones("public-zone").Build(),
-			expectedCredentialRequest: newCredentialsRequest("externaldns-credentials-request-gcp").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredGCPProviderSpec).build(),
+			expectedCredentialRequest: newCredentialsRequest("external-dns-test").withOwner().withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials-test", "external-dns-operator").withProviderSpec(desiredGCPProviderSpec).build(),
 		},
 	}
 	for _, tc := range testCases {
@@ -163,10 +190,83 @@ func TestEnsureCredentialsRequest(t *testing.T) {
 	}
 }
 
+func TestDeleteLegacyExternalDNSCredentialsRequest(t *testing.T) {
+	legacyCR := newCredentialsRequest("externaldns-credentials-request-aws").withSAs("external-dns-operator").withSecret("externaldns-cloud-credentials", "external-dns-operator").withProviderSpec(desiredAWSProviderSpecAllZones).build()
+	otherAWS := test.NewExternalDNS("other").WithAWS().WithRouteSource().Build()
+	otherGCP := test.NewExternalDNS("other").WithGCP().WithRouteSource().Build()
+
+	testCases := []struct {
+		name            string
+		existingObjects []runtime.Object
+		expectedDeleted bool
+	}{
+		{
+			name:            "No legacy credentials request",
+			existingObjects: []runtime.Object{testCloudCredentialsSecret(test.Name)},
+			expectedDeleted: true,
+		},
+		{
+			name:            "Legacy credentials request is kept until the secret of the instance is provisioned",
+			existingObjects: []runtime.Object{legacyCR},
+			expectedDeleted: false,
+		},
+		{
+			name:            "Legacy credentials request is deleted once the secret of the instance is provisioned",
+			existingObjects: []runtime.Object{legacyCR, testCloudCredentialsSecret(test.Name)},
+			expectedDeleted: true,
+		},
+		{
+			name:            "Legacy credentials request is kept until the secrets of all the instances are provisioned",
+			existingObjects: []runtime.Object{legacyCR, testCloudCredentialsSecret(test.Name), otherAWS},
+			expectedDeleted: false,
+		},
+		{
+			name:            "Instances of other provider types are ignored",
+			existingObjects: []runtime.Object{legacyCR, testCloudCredentialsSecret(test.Name), otherGCP},
+			expectedDeleted: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := test.NewExternalDNS(test.Name).WithAWS().WithRouteSource().WithZones("public-zone").Build()
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(append(tc.existingObjects, extDNS)...).Build()
+			r := &reconciler{
+				client: cl,
+				scheme: test.Scheme,
+				config: Config{
+					Namespace:         test.OperandNamespace,
+					OperatorNamespace: test.OperatorNamespace,
+				},
+				log: zap.New(zap.UseDevMode(true)),
+			}
+
+			if err := r.deleteLegacyExternalDNSCredentialsRequest(context.TODO(), extDNS); err != nil {
+				t.Fatalf("unexpected error from deleteLegacyExternalDNSCredentialsRequest: %v", err)
+			}
+
+			legacyName := controller.ExternalDNSLegacyCredentialsRequestName(extDNS)
+			err := cl.Get(context.TODO(), legacyName, &cco.CredentialsRequest{})
+			if gotDeleted := errors.IsNotFound(err); gotDeleted != tc.expectedDeleted {
+				t.Errorf("expected legacy credentials request deleted %t, got %v", tc.expectedDeleted, err)
+			}
+		})
+	}
+}
+
 //
 // Helper functions
 //
 
+func testCloudCredentialsSecret(extDNSName string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "externaldns-cloud-credentials-" + extDNSName,
+			Namespace: test.OperatorNamespace,
+		},
+	}
+}
+
 func decodeGCPProviderSpec(gotCredentialRequest, expectedCredentialRequest cco.CredentialsRequest) (gotDecodedGCPSpec, expectedDecodedGCPSpec cco.GCPProviderSpec, err error) {
 
 	codec, _ := cco.NewCodec()
@@ -242,6 +342,12 @@ func newCredentialsRequest(name string) *credentialsRequestBuilder {
 	}
 }
 
+func (b *credentialsRequestBuilder) withOwner() *credentialsRequestBuilder {
+	owner := &operatorv1beta1.ExternalDNS{ObjectMeta: metav1.ObjectMeta{Name: test.Name}}
+	_ = controllerutil.SetControllerReference(owner, b.req, test.Scheme)
+	return b
+}
+
 func (b *credentialsRequestBuilder) withSAs(sa ...string) *credentialsRequestBuilder {
 	b.req.Spec.ServiceAccountNames = sa
 	return b
@@ -276,14 +382,49 @@ func desiredAWSProviderSpec() runtime.Object {
 				Effect: "Allow",
 				Action: []string{
 					"route53:ChangeResourceRecordSets",
+					"route53:ListResourceRecordSets",
 				},
-				Resource: "arn:aws:route53:::hostedzone/*",
+				Resource: "arn:aws:route53:::hostedzone/public-zone",
 			},
 			{
 				Effect: "Allow",
 				Action: []string{
 					"route53:ListHostedZones",
+					"tag:GetResources",
+					"sts:AssumeRole",
+				},
+				Resource: "*",
+			},
+		},
+	}
+}
+
+func desiredAWSProviderSpecMultipleZones() runtime.Object {
+	return &cco.AWSProviderSpec{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "AWSProviderSpec",
+		},
+		StatementEntries: []cco.StatementEntry{
+			{
+				Effect: "Allow",
+				Action: []string{
+					"route53:ChangeResourceRecordSets",
+					"route53:ListResourceRecordSets",
+				},
+				Resource: "arn:aws:route53:::hostedzone/public-zone",
+			},
+			{
+				Effect: "Allow",
+				Action: []string{
+					"route53:ChangeResourceRecordSets",
 					"route53:ListResourceRecordSets",
+				},
+				Resource: "arn:aws:route53:::hostedzone/private-zone",
+			},
+			{
+				Effect: "Allow",
+				Action: []string{
+					"route53:ListHostedZones",
 					"tag:GetResources",
 					"sts:AssumeRole",
 				},
@@ -293,7 +434,7 @@ func desiredAWSProviderSpec() runtime.Object {
 	}
 }
 
-func desiredAWSProviderSpecGovARN() runtime.Object {
+func desiredAWSProviderSpecAllZones() runtime.Object {
 	return &cco.AWSProviderSpec{
 		TypeMeta: metav1.TypeMeta{
 			Kind: "AWSProviderSpec",
@@ -303,14 +444,41 @@ func desiredAWSProviderSpecGovARN() runtime.Object {
 				Effect: "Allow",
 				Action: []string{
 					"route53:ChangeResourceRecordSets",
+					"route53:ListResourceRecordSets",
 				},
-				Resource: "arn:aws-us-gov:route53:::hostedzone/*",
+				Resource: "arn:aws:route53:::hostedzone/*",
 			},
 			{
 				Effect: "Allow",
 				Action: []string{
 					"route53:ListHostedZones",
+					"tag:GetResources",
+					"sts:AssumeRole",
+				},
+				Resource: "*",
+			},
+		},
+	}
+}
+
+func desiredAWSProviderSpecGovARN() runtime.Object {
+	return &cco.AWSProviderSpec{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "AWSProviderSpec",
+		},
+		StatementEntries: []cco.StatementEntry{
+			{
+				Effect: "Allow",
+				Action: []string{
+					"route53:ChangeResourceRecordSets",
 					"route53:ListResourceRecordSets",
+				},
+				Resource: "arn:aws-us-gov:route53:::hostedzone/public-zone",
+			},
+			{
+				Effect: "Allow",
+				Action: []string{
+					"route53:ListHostedZones",
 					"tag:GetResources",
 					"sts:AssumeRole",
 				},
@@ -370,7 +538,30 @@ func desiredAzureProviderSpec() runtime.Object {
 			Kind: "AzureProviderSpec",
 		},
 		RoleBindings: []cco.RoleBinding{
-			{Role: "Contributor"},
+			{Role: "DNS Zone Contributor"},
+		},
+	}
+}
+
+func desiredAzureProviderSpecAllZones() runtime.Object {
+	return &cco.AzureProviderSpec{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "AzureProviderSpec",
+		},
+		RoleBindings: []cco.RoleBinding{
+			{Role: "DNS Zone Contributor"},
+			{Role: "Private DNS Zone Contributor"},
+		},
+	}
+}
+
+func desiredAzureProviderSpecPrivateZone() runtime.Object {
+	return &cco.AzureProviderSpec{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "AzureProviderSpec",
+		},
+		RoleBindings: []cco.RoleBinding{
+			{Role: "Private DNS Zone Contributor"},
 		},
 	}
 }
@@ -23,15 +23,19 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -47,6 +51,11 @@ const (
 	externalDNSProviderTypeAzurePrivate = "azure-private-dns"
 	externalDNSProviderTypeBlueCat      = "bluecat"
 	externalDNSProviderTypeInfoblox     = "infoblox"
+	externalDNSProviderTypeCloudflare   = "cloudflare"
+	externalDNSProviderTypeRFC2136      = "rfc2136"
+	externalDNSProviderTypeWebhook      = "webhook"
+	externalDNSPolicySync               = "sync"
+	externalDNSLogLevelDebug            = "debug"
 	appNameLabel                        = "app.kubernetes.io/name"
 	appInstanceLabel                    = "app.kubernetes.io/instance"
 	masterNodeRoleLabel                 = "node-role.kubernetes.io/master"
@@ -55,23 +64,66 @@ const (
 	azurePrivateDNSZonesResourceSubStr  = "privatednszones"
 	credentialsAnnotation               = "externaldns.olm.openshift.io/credentials-secret-hash"
 	trustedCAAnnotation                 = "externaldns.olm.openshift.io/trusted-ca-configmap-hash"
+	extraAnnotationsAnnotation          = "externaldns.olm.openshift.io/extra-annotations"
+	extraLabelsAnnotation               = "externaldns.olm.openshift.io/extra-labels"
 )
 
 // providerStringTable maps ExternalDNSProviderType values from the
 // ExternalDNS operator API to the provider string argument expected by ExternalDNS.
 var providerStringTable = map[operatorv1beta1.ExternalDNSProviderType]string{
-	operatorv1beta1.ProviderTypeAWS:      externalDNSProviderTypeAWS,
-	operatorv1beta1.ProviderTypeGCP:      externalDNSProviderTypeGCP,
-	operatorv1beta1.ProviderTypeAzure:    externalDNSProviderTypeAzure,
-	operatorv1beta1.ProviderTypeBlueCat:  externalDNSProviderTypeBlueCat,
-	operatorv1beta1.ProviderTypeInfoblox: externalDNSProviderTypeInfoblox,
+	operatorv1beta1.ProviderTypeAWS:        externalDNSProviderTypeAWS,
+	operatorv1beta1.ProviderTypeGCP:        externalDNSProviderTypeGCP,
+	operatorv1beta1.ProviderTypeAzure:      externalDNSProviderTypeAzure,
+	operatorv1beta1.ProviderTypeBlueCat:    externalDNSProviderTypeBlueCat,
+	operatorv1beta1.ProviderTypeInfoblox:   externalDNSProviderTypeInfoblox,
+	operatorv1beta1.ProviderTypeCloudflare: externalDNSProviderTypeCloudflare,
+	operatorv1beta1.ProviderTypeRFC2136:    externalDNSProviderTypeRFC2136,
+	operatorv1beta1.ProviderTypeWebhook:    externalDNSProviderTypeWebhook,
 }
 
 // sourceStringTable maps ExternalDNSSourceType values from the
 // ExternalDNS operator API to the source string argument expected by ExternalDNS.
 var sourceStringTable = map[operatorv1beta1.ExternalDNSSourceType]string{
-	operatorv1beta1.SourceTypeRoute:   "openshift-route",
-	operatorv1beta1.SourceTypeService: "service",
+	operatorv1beta1.SourceTypeRoute:            "openshift-route",
+	operatorv1beta1.SourceTypeService:          "service",
+	operatorv1beta1.SourceTypeIngress:          "ingress",
+	operatorv1beta1.SourceTypeCRD:              "crd",
+	operatorv1beta1.SourceTypeGatewayHTTPRoute: "gateway-httproute",
+	operatorv1beta1.SourceTypeGatewayGRPCRoute: "gateway-grpcroute",
+	operatorv1beta1.SourceTypeGatewayTLSRoute:  "gateway-tlsroute",
+	operatorv1beta1.SourceTypeGatewayTCPRoute:  "gateway-tcproute",
+	operatorv1beta1.SourceTypeGatewayUDPRoute:  "gateway-udproute",
+}
+
+// policyStringTable maps ExternalDNSPolicy values from the
+// ExternalDNS operator API to the policy string argument expected by ExternalDNS.
+var policyStringTable = map[operatorv1beta1.ExternalDNSPolicy]string{
+	operatorv1beta1.PolicySync:       externalDNSPolicySync,
+	operatorv1beta1.PolicyUpsertOnly: "upsert-only",
+	operatorv1beta1.PolicyCreateOnly: "create-only",
+}
+
+// logLevelStringTable maps ExternalDNSLogLevel values from the
+// ExternalDNS operator API to the log level string argument expected by ExternalDNS.
+var logLevelStringTable = map[operatorv1beta1.ExternalDNSLogLevel]string{
+	operatorv1beta1.LogLevelDebug: externalDNSLogLevelDebug,
+	operatorv1beta1.LogLevelInfo:  "info",
+	operatorv1beta1.LogLevelWarn:  "warning",
+	operatorv1beta1.LogLevelError: "error",
+}
+
+// gatewayRouteSourceTypes is the set of the Gateway API route source types.
+var gatewayRouteSourceTypes = map[operatorv1beta1.ExternalDNSSourceType]bool{
+	operatorv1beta1.SourceTypeGatewayHTTPRoute: true,
+	operatorv1beta1.SourceTypeGatewayGRPCRoute: true,
+	operatorv1beta1.SourceTypeGatewayTLSRoute:  true,
+	operatorv1beta1.SourceTypeGatewayTCPRoute:  true,
+	operatorv1beta1.SourceTypeGatewayUDPRoute:  true,
+}
+
+// externalDNSSources returns the source and the additional sources of the given ExternalDNS.
+func externalDNSSources(extDNS *operatorv1beta1.ExternalDNS) []operatorv1beta1.ExternalDNSSourceUnion {
+	return append([]operatorv1beta1.ExternalDNSSourceUnion{extDNS.Spec.Source.ExternalDNSSourceUnion}, extDNS.Spec.AdditionalSources...)
 }
 
 type deploymentConfig struct {
@@ -85,6 +137,7 @@ type deploymentConfig struct {
 	secretHash             string
 	trustedCAConfigMapName string
 	trustedCAConfigMapHash string
+	metricsProxyImage      string
 }
 
 // ensureExternalDNSDeployment ensures that the externalDNS deployment exists.
@@ -119,6 +172,7 @@ func (r *reconciler) ensureExternalDNSDeployment(ctx context.Context, namespace,
 		credSecretHash,
 		trustCAConfigMapName,
 		trustCAConfigMapHash,
+		r.config.MetricsProxyImage,
 	})
 	if err != nil {
 		return false, nil, fmt.Errorf("failed to build externalDNS deployment: %w", err)
@@ -193,37 +247,30 @@ func (r *reconciler) currentExternalDNSTrustedCAConfigMap(ctx context.Context, n
 
 // desiredExternalDNSDeployment returns the desired deployment resource.
 func desiredExternalDNSDeployment(cfg *deploymentConfig) (*appsv1.Deployment, error) {
+	// a single replica publishes the records:
+	// ExternalDNS doesn't elect a leader among multiple replicas
 	replicas := int32(1)
+	// the suspended instance keeps the strategy and the pod template
+	// to resume with the same pods
+	if cfg.externalDNS.Spec.Suspend {
+		replicas = 0
+	}
 
 	matchLbl := map[string]string{
 		appNameLabel:     controller.ExternalDNSBaseName,
 		appInstanceLabel: cfg.externalDNS.Name,
 	}
 
-	nodeSelectorLbl := map[string]string{
-		osLabel: linuxOS,
-	}
-
-	tolerations := []corev1.Toleration{
-		{
-			Key:      masterNodeRoleLabel,
-			Operator: corev1.TolerationOpExists,
-			Effect:   corev1.TaintEffectNoSchedule,
-		},
-	}
-
-	annotations := map[string]string{
-		credentialsAnnotation: cfg.secretHash,
-	}
-
-	if cfg.trustedCAConfigMapHash != "" {
-		annotations[trustedCAAnnotation] = cfg.trustedCAConfigMapHash
+	podSpec, err := desiredExternalDNSPodSpec(cfg, false)
+	if err != nil {
+		return nil, err
 	}
 
 	depl := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      controller.ExternalDNSResourceName(cfg.externalDNS),
 			Namespace: cfg.namespace,
+			Labels:    controller.ExternalDNSOperandLabels(),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
@@ -235,40 +282,128 @@ func desiredExternalDNSDeployment(cfg *deploymentConfig) (*appsv1.Deployment, er
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      matchLbl,
-					Annotations: annotations,
-				},
-				Spec: corev1.PodSpec{
-					ServiceAccountName: cfg.serviceAccount.Name,
-					NodeSelector:       nodeSelectorLbl,
-					Tolerations:        tolerations,
+					Labels:      desiredExternalDNSPodLabels(cfg, matchLbl),
+					Annotations: desiredExternalDNSPodAnnotations(cfg),
 				},
+				Spec: *podSpec,
 			},
 		},
 	}
 
+	return depl, nil
+}
+
+// desiredExternalDNSPodLabels returns the labels of ExternalDNS pod:
+// the given selector labels and the extra labels from the deployment options.
+// The selector labels take precedence over the extra ones.
+func desiredExternalDNSPodLabels(cfg *deploymentConfig, selectorLabels map[string]string) map[string]string {
+	labels := map[string]string{}
+	for k, v := range cfg.externalDNS.Spec.Deployment.Labels {
+		labels[k] = v
+	}
+	for k, v := range selectorLabels {
+		labels[k] = v
+	}
+	return labels
+}
+
+// desiredExternalDNSPodAnnotations returns the annotations of ExternalDNS pod:
+// the extra annotations from the deployment options and the ones
+// which trigger the rollout when the credentials or the trusted CA change.
+// The keys of the extra annotations and labels are listed in dedicated annotations
+// to remove them from the pod template once they are dropped from the deployment options.
+func desiredExternalDNSPodAnnotations(cfg *deploymentConfig) map[string]string {
+	annotations := map[string]string{}
+	extraKeys := []string{}
+	for k, v := range cfg.externalDNS.Spec.Deployment.Annotations {
+		annotations[k] = v
+		extraKeys = append(extraKeys, k)
+	}
+	if len(extraKeys) > 0 {
+		sort.Strings(extraKeys)
+		annotations[extraAnnotationsAnnotation] = strings.Join(extraKeys, ",")
+	}
+	extraLabelKeys := []string{}
+	for k := range cfg.externalDNS.Spec.Deployment.Labels {
+		// the selector labels are always set
+		if k == appNameLabel || k == appInstanceLabel {
+			continue
+		}
+		extraLabelKeys = append(extraLabelKeys, k)
+	}
+	if len(extraLabelKeys) > 0 {
+		sort.Strings(extraLabelKeys)
+		annotations[extraLabelsAnnotation] = strings.Join(extraLabelKeys, ",")
+	}
+	annotations[credentialsAnnotation] = cfg.secretHash
+
+	if cfg.trustedCAConfigMapHash != "" {
+		annotations[trustedCAAnnotation] = cfg.trustedCAConfigMapHash
+	}
+
+	return annotations
+}
+
+// desiredExternalDNSPodSpec returns the spec of ExternalDNS pod.
+// The containers of the pod run a single cleanup of the DNS records if cleanup is true.
+func desiredExternalDNSPodSpec(cfg *deploymentConfig, cleanup bool) (*corev1.PodSpec, error) {
+	nodeSelectorLbl := map[string]string{
+		osLabel: linuxOS,
+	}
+
+	tolerations := []corev1.Toleration{
+		{
+			Key:      masterNodeRoleLabel,
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		},
+	}
+
+	deploymentOpts := cfg.externalDNS.Spec.Deployment
+	if len(deploymentOpts.NodeSelector) != 0 {
+		nodeSelectorLbl = deploymentOpts.NodeSelector
+	}
+	if len(deploymentOpts.Tolerations) != 0 {
+		tolerations = deploymentOpts.Tolerations
+	}
+
+	podSpec := &corev1.PodSpec{
+		ServiceAccountName:        cfg.serviceAccount.Name,
+		NodeSelector:              nodeSelectorLbl,
+		Tolerations:               tolerations,
+		Affinity:                  deploymentOpts.Affinity,
+		PriorityClassName:         deploymentOpts.PriorityClassName,
+		TopologySpreadConstraints: deploymentOpts.TopologySpreadConstraints,
+	}
+
 	provider, ok := providerStringTable[cfg.externalDNS.Spec.Provider.Type]
 	if !ok {
 		return nil, fmt.Errorf("unsupported provider: %q", cfg.externalDNS.Spec.Provider.Type)
 	}
-	source, ok := sourceStringTable[cfg.externalDNS.Spec.Source.Type]
-	if !ok {
-		return nil, fmt.Errorf("unsupported source type: %q", cfg.externalDNS.Spec.Source.Type)
+	var sources []string
+	for _, s := range externalDNSSources(cfg.externalDNS) {
+		source, ok := sourceStringTable[s.Type]
+		if !ok {
+			return nil, fmt.Errorf("unsupported source type: %q", s.Type)
+		}
+		sources = append(sources, source)
 	}
 
-	vbld := newExternalDNSVolumeBuilder(provider, cfg.secret, cfg.trustedCAConfigMapName)
+	vbld := newExternalDNSVolumeBuilder(provider, cfg.secret, cfg.trustedCAConfigMapName, cfg.externalDNS)
 	volumes := vbld.build()
-	depl.Spec.Template.Spec.Volumes = append(depl.Spec.Template.Spec.Volumes, volumes...)
+	podSpec.Volumes = append(podSpec.Volumes, volumes...)
 
 	cbld := &externalDNSContainerBuilder{
-		image:          cfg.image,
-		provider:       provider,
-		source:         source,
-		secretName:     cfg.secret,
-		volumes:        volumes,
-		externalDNS:    cfg.externalDNS,
-		isOpenShift:    cfg.isOpenShift,
-		platformStatus: cfg.platformStatus,
+		image:             cfg.image,
+		provider:          provider,
+		sources:           sources,
+		secretName:        cfg.secret,
+		volumes:           volumes,
+		externalDNS:       cfg.externalDNS,
+		isOpenShift:       cfg.isOpenShift,
+		platformStatus:    cfg.platformStatus,
+		cleanup:           cleanup,
+		metricsProxyImage: cfg.metricsProxyImage,
 	}
 
 	if len(cfg.externalDNS.Spec.Zones) == 0 {
@@ -285,7 +420,7 @@ func desiredExternalDNSDeployment(cfg *deploymentConfig) (*appsv1.Deployment, er
 			if err != nil {
 				return nil, fmt.Errorf("failed to build container: %w", err)
 			}
-			depl.Spec.Template.Spec.Containers = append(depl.Spec.Template.Spec.Containers, *container)
+			podSpec.Containers = append(podSpec.Containers, *container)
 		}
 	} else {
 		for _, zone := range cfg.externalDNS.Spec.Zones {
@@ -293,10 +428,49 @@ func desiredExternalDNSDeployment(cfg *deploymentConfig) (*appsv1.Deployment, er
 			if err != nil {
 				return nil, fmt.Errorf("failed to build container for zone %s: %w", zone, err)
 			}
-			depl.Spec.Template.Spec.Containers = append(depl.Spec.Template.Spec.Containers, *container)
+			podSpec.Containers = append(podSpec.Containers, *container)
 		}
 	}
-	return depl, nil
+
+	// every ExternalDNS container gets its own proxy:
+	// kube-rbac-proxy supports a single upstream
+	if !cleanup && cfg.externalDNS.Spec.Metrics.Exposure == operatorv1beta1.MetricsExposureKubeRBACProxy {
+		externalDNSContainers := len(podSpec.Containers)
+		for seq := 0; seq < externalDNSContainers; seq++ {
+			podSpec.Containers = append(podSpec.Containers, *cbld.buildMetricsProxy(seq))
+		}
+		if cfg.isOpenShift {
+			podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+				Name: metricsCertVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: controller.ExternalDNSMetricsServiceName(cfg.namespace, cfg.externalDNS).Name,
+					},
+				},
+			})
+		}
+	}
+
+	// the webhook provider runs next to ExternalDNS container
+	// and is reached on localhost
+	if provider == externalDNSProviderTypeWebhook {
+		if sidecar := cbld.buildWebhookProvider(); sidecar != nil {
+			if cleanup {
+				// the sidecar must not hold the completion of the cleanup pod:
+				// it's started as a native sidecar which is stopped once ExternalDNS containers exit
+				sidecar.RestartPolicy = ptr.To(corev1.ContainerRestartPolicyAlways)
+				podSpec.InitContainers = append(podSpec.InitContainers, *sidecar)
+			} else {
+				podSpec.Containers = append(podSpec.Containers, *sidecar)
+			}
+		}
+	}
+
+	if cleanup {
+		podSpec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	return podSpec, nil
 }
 
 // createExternalDNSDeployment creates the given deployment using the reconciler's client.
@@ -308,6 +482,32 @@ func (r *reconciler) createExternalDNSDeployment(ctx context.Context, depl *apps
 	return nil
 }
 
+// deleteExternalDNSDeployment deletes the externalDNS deployment and waits for its pods to be gone.
+// Returns a Boolean value indicating whether the deployment is gone, and an error when relevant.
+func (r *reconciler) deleteExternalDNSDeployment(ctx context.Context, nsName types.NamespacedName) (bool, error) {
+	exist, current, err := r.currentExternalDNSDeployment(ctx, nsName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get externalDNS deployment: %w", err)
+	}
+	if !exist {
+		return true, nil
+	}
+	if current.DeletionTimestamp != nil {
+		// deletion is in progress
+		return false, nil
+	}
+
+	// foreground deletion keeps the deployment until all its pods are deleted
+	if err := r.client.Delete(ctx, current, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to delete externalDNS deployment %s/%s: %w", current.Namespace, current.Name, err)
+	}
+	r.log.Info("deleted externalDNS deployment", "namespace", current.Namespace, "name", current.Name)
+	return false, nil
+}
+
 // updateExternalDNSDeployment updates the in-cluster externalDNS deployment.
 // Returns a boolean if an update was made, and an error when relevant.
 func (r *reconciler) updateExternalDNSDeployment(ctx context.Context, current, desired *appsv1.Deployment) (bool, error) {
@@ -356,6 +556,14 @@ func externalDNSDeploymentChanged(current, expected *appsv1.Deployment) (bool, *
 		changed = true
 	}
 
+	if externalDNSPodLabelsChanged(current, expected, updated) {
+		changed = true
+	}
+
+	if externalDNSSchedulingChanged(current, expected, updated) {
+		changed = true
+	}
+
 	if externalDNSContainersChanged(current, expected, updated) {
 		changed = true
 	}
@@ -367,7 +575,9 @@ func externalDNSDeploymentChanged(current, expected *appsv1.Deployment) (bool, *
 	return changed, updated
 }
 
-// externalDNSAnnotationsChanged returns true if any annotation from the podspec differs from the expected.```
+// externalDNSAnnotationsChanged returns true if any annotation from the podspec differs from the expected.
+// The annotations set by the operator which are not expected anymore are removed,
+// the annotations set by others are kept.
 func externalDNSAnnotationsChanged(current, expected, updated *appsv1.Deployment) bool {
 	changed := false
 	if len(current.Spec.Template.Annotations) == 0 {
@@ -384,6 +594,96 @@ func externalDNSAnnotationsChanged(current, expected, updated *appsv1.Deployment
 			changed = true
 		}
 	}
+	for _, key := range operatorPodAnnotationKeys(current) {
+		if _, expectedExists := expected.Spec.Template.Annotations[key]; expectedExists {
+			continue
+		}
+		if _, currentExists := current.Spec.Template.Annotations[key]; currentExists {
+			delete(updated.Spec.Template.Annotations, key)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// operatorPodAnnotationKeys returns the keys of the annotations which the operator
+// may have set on the pod template of the given deployment:
+// the optional annotations of the operator and the extra annotations listed in the pod template.
+func operatorPodAnnotationKeys(depl *appsv1.Deployment) []string {
+	keys := []string{trustedCAAnnotation, extraAnnotationsAnnotation, extraLabelsAnnotation}
+	if extraKeys := depl.Spec.Template.Annotations[extraAnnotationsAnnotation]; extraKeys != "" {
+		keys = append(keys, strings.Split(extraKeys, ",")...)
+	}
+	return keys
+}
+
+// externalDNSPodLabelsChanged returns true if any label from the pod template differs from the expected.
+// The extra labels listed in the pod template which are not expected anymore are removed,
+// the labels set by others are kept.
+func externalDNSPodLabelsChanged(current, expected, updated *appsv1.Deployment) bool {
+	changed := false
+	if len(current.Spec.Template.Labels) == 0 {
+		if len(expected.Spec.Template.Labels) == 0 {
+			return false
+		}
+		updated.Spec.Template.Labels = expected.Spec.Template.Labels
+		return true
+	}
+	for expectedKey, expectedValue := range expected.Spec.Template.Labels {
+		currentVal, currentExists := current.Spec.Template.Labels[expectedKey]
+		if !currentExists || currentVal != expectedValue {
+			updated.Spec.Template.Labels[expectedKey] = expectedValue
+			changed = true
+		}
+	}
+	for _, key := range operatorPodLabelKeys(current) {
+		if _, expectedExists := expected.Spec.Template.Labels[key]; expectedExists {
+			continue
+		}
+		if _, currentExists := current.Spec.Template.Labels[key]; currentExists {
+			delete(updated.Spec.Template.Labels, key)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// operatorPodLabelKeys returns the keys of the extra labels which the operator
+// set on the pod template of the given deployment, as listed in the pod template annotations.
+func operatorPodLabelKeys(depl *appsv1.Deployment) []string {
+	extraKeys := depl.Spec.Template.Annotations[extraLabelsAnnotation]
+	if extraKeys == "" {
+		return nil
+	}
+	return strings.Split(extraKeys, ",")
+}
+
+// externalDNSSchedulingChanged returns true if the scheduling fields of the pod template differ from the expected.
+func externalDNSSchedulingChanged(current, expected, updated *appsv1.Deployment) bool {
+	changed := false
+	currentSpec, expectedSpec := &current.Spec.Template.Spec, &expected.Spec.Template.Spec
+
+	if !equality.Semantic.DeepEqual(currentSpec.NodeSelector, expectedSpec.NodeSelector) {
+		updated.Spec.Template.Spec.NodeSelector = expectedSpec.NodeSelector
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(currentSpec.Tolerations, expectedSpec.Tolerations) {
+		updated.Spec.Template.Spec.Tolerations = expectedSpec.Tolerations
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(currentSpec.Affinity, expectedSpec.Affinity) {
+		updated.Spec.Template.Spec.Affinity = expectedSpec.Affinity
+		changed = true
+	}
+	if currentSpec.PriorityClassName != expectedSpec.PriorityClassName {
+		updated.Spec.Template.Spec.PriorityClassName = expectedSpec.PriorityClassName
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(currentSpec.TopologySpreadConstraints, expectedSpec.TopologySpreadConstraints) {
+		updated.Spec.Template.Spec.TopologySpreadConstraints = expectedSpec.TopologySpreadConstraints
+		changed = true
+	}
+
 	return changed
 }
 
@@ -410,6 +710,22 @@ func externalDNSContainersChanged(current, expected, updated *appsv1.Deployment)
 				updated.Spec.Template.Spec.Containers[currCont.Index].Env = expCont.Env
 				changed = true
 			}
+			if !equality.Semantic.DeepEqual(currCont.Resources, expCont.Resources) {
+				updated.Spec.Template.Spec.Containers[currCont.Index].Resources = expCont.Resources
+				changed = true
+			}
+			if !equality.Semantic.DeepEqual(currCont.Ports, expCont.Ports) {
+				updated.Spec.Template.Spec.Containers[currCont.Index].Ports = expCont.Ports
+				changed = true
+			}
+			if !equality.Semantic.DeepEqual(currCont.StartupProbe, expCont.StartupProbe) {
+				updated.Spec.Template.Spec.Containers[currCont.Index].StartupProbe = expCont.StartupProbe
+				changed = true
+			}
+			if !equality.Semantic.DeepEqual(currCont.ReadinessProbe, expCont.ReadinessProbe) {
+				updated.Spec.Template.Spec.Containers[currCont.Index].ReadinessProbe = expCont.ReadinessProbe
+				changed = true
+			}
 			if vmChanged, updatedVolumeMounts := volumeMountsChanged(currCont.VolumeMounts, expCont.VolumeMounts, updated.Spec.Template.Spec.Containers[currCont.Index].VolumeMounts); vmChanged {
 				updated.Spec.Template.Spec.Containers[currCont.Index].VolumeMounts = updatedVolumeMounts
 				changed = true
@@ -440,6 +756,21 @@ func externalDNSContainersChanged(current, expected, updated *appsv1.Deployment)
 	return changed
 }
 
+// operatorVolumeNames are the names of the volumes added by the operator to the pod template.
+// They are removed from the pod template once the spec no longer needs them.
+var operatorVolumeNames = map[string]bool{
+	trustedCAVolumeName:                true,
+	awsCredentialsVolumeName:           true,
+	boundSATokenVolumeName:             true,
+	awsWebIdentityTokenVolumeName:      true,
+	azureConfigVolumeName:              true,
+	azureFederatedTokenVolumeName:      true,
+	gcpCredentialsVolumeName:           true,
+	gcpWorkloadIdentityTokenVolumeName: true,
+	blueCatConfigVolumeName:            true,
+	metricsCertVolumeName:              true,
+}
+
 // externalDNSVolumesChanged returns true if the current volumes differ from the expected.
 func externalDNSVolumesChanged(current, expected, updated *appsv1.Deployment) bool {
 	if len(current.Spec.Template.Spec.Volumes) == 0 {
@@ -473,6 +804,19 @@ func externalDNSVolumesChanged(current, expected, updated *appsv1.Deployment) bo
 		}
 	}
 
+	// remove the operator's volumes which are no longer expected
+	// (e.g. the credentials secret after the switch to the web identity)
+	updatedNew := []corev1.Volume{}
+	for _, updVol := range updated.Spec.Template.Spec.Volumes {
+		if _, found := expectedVolumeMap[updVol.Name]; found || !operatorVolumeNames[updVol.Name] {
+			updatedNew = append(updatedNew, updVol)
+		}
+	}
+	if len(updated.Spec.Template.Spec.Volumes) != len(updatedNew) {
+		updated.Spec.Template.Spec.Volumes = updatedNew
+		changed = true
+	}
+
 	return changed
 }
 
@@ -527,6 +871,18 @@ func volumeMountsChanged(current, expected, updated []corev1.VolumeMount) (bool,
 		}
 	}
 
+	// remove the mounts of the operator's volumes which are no longer expected
+	updatedNew := []corev1.VolumeMount{}
+	for _, updVol := range updated {
+		if _, found := expectedVolumeMountMap[updVol.Name]; found || !operatorVolumeNames[updVol.Name] {
+			updatedNew = append(updatedNew, updVol)
+		}
+	}
+	if len(updated) != len(updatedNew) {
+		updated = updatedNew
+		changed = true
+	}
+
 	return changed, updated
 }
 
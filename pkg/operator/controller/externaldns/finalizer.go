@@ -0,0 +1,190 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+// ensureExternalDNSFinalizer ensures that the cleanup finalizer is set on the given externalDNS
+// only if its deletion policy requests the cleanup of the DNS records.
+func (r *reconciler) ensureExternalDNSFinalizer(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) error {
+	wanted := externalDNS.Spec.DeletionPolicy == operatorv1beta1.DeletionPolicyCleanupRecords
+	if wanted == controllerutil.ContainsFinalizer(externalDNS, controller.ExternalDNSCleanupFinalizer) {
+		return nil
+	}
+
+	patch := client.MergeFrom(externalDNS.DeepCopy())
+	if wanted {
+		controllerutil.AddFinalizer(externalDNS, controller.ExternalDNSCleanupFinalizer)
+	} else {
+		controllerutil.RemoveFinalizer(externalDNS, controller.ExternalDNSCleanupFinalizer)
+	}
+	if err := r.client.Patch(ctx, externalDNS, patch); err != nil {
+		return fmt.Errorf("failed to update finalizers of externalDNS %s: %w", externalDNS.Name, err)
+	}
+	r.log.Info("updated externalDNS finalizers", "name", externalDNS.Name, "finalizers", externalDNS.Finalizers)
+	return nil
+}
+
+// removeExternalDNSFinalizer removes the cleanup finalizer from the given externalDNS.
+func (r *reconciler) removeExternalDNSFinalizer(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) error {
+	patch := client.MergeFrom(externalDNS.DeepCopy())
+	controllerutil.RemoveFinalizer(externalDNS, controller.ExternalDNSCleanupFinalizer)
+	if err := r.client.Patch(ctx, externalDNS, patch); err != nil {
+		return fmt.Errorf("failed to remove finalizer from externalDNS %s: %w", externalDNS.Name, err)
+	}
+	r.log.Info("removed externalDNS finalizer", "name", externalDNS.Name)
+	return nil
+}
+
+// finalizeExternalDNS cleans up the DNS records of the given externalDNS which is being deleted.
+// The operand deployment is deleted first to prevent it from recreating the records,
+// then the cleanup job is run. The progress of the cleanup is reported in the status,
+// its result is reported by an event when the finalizer is removed once the job finished.
+func (r *reconciler) finalizeExternalDNS(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS) (reconcile.Result, error) {
+	if !controllerutil.ContainsFinalizer(externalDNS, controller.ExternalDNSCleanupFinalizer) {
+		return reconcile.Result{}, nil
+	}
+
+	// the deletion policy was changed to keep the records
+	if externalDNS.Spec.DeletionPolicy != operatorv1beta1.DeletionPolicyCleanupRecords {
+		return reconcile.Result{}, r.removeExternalDNSFinalizer(ctx, externalDNS)
+	}
+
+	// the webhook rejects the cleanup in the dry run mode but it may be disabled:
+	// the instance was only planning the changes, its records must not be deleted
+	if externalDNS.Spec.DryRun {
+		r.recordCleanupEvent(externalDNS, metav1.Condition{
+			Type:    ExternalDNSRecordsCleanedUpConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "DryRun",
+			Message: "The instance runs in the dry run mode, the DNS records were not cleaned up",
+		})
+		return reconcile.Result{}, r.removeExternalDNSFinalizer(ctx, externalDNS)
+	}
+
+	// the webhook provider of the cleanup job runs as a native sidecar,
+	// without them the provider would never exit and the job would never complete
+	if externalDNS.Spec.Provider.Type == operatorv1beta1.ProviderTypeWebhook && !r.config.NativeSidecars {
+		r.recordCleanupEvent(externalDNS, metav1.Condition{
+			Type:    ExternalDNSRecordsCleanedUpConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "NativeSidecarsNotSupported",
+			Message: "The cleanup of the webhook provider requires the native sidecars of Kubernetes 1.29 or later, the DNS records were not cleaned up",
+		})
+		return reconcile.Result{}, r.removeExternalDNSFinalizer(ctx, externalDNS)
+	}
+
+	operandNamespace := controller.ExternalDNSOperandNamespace(externalDNS, r.config.Namespace)
+
+	deploymentGone, err := r.deleteExternalDNSDeployment(ctx, types.NamespacedName{Namespace: operandNamespace, Name: controller.ExternalDNSResourceName(externalDNS)})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !deploymentGone {
+		// the deletion of the deployment will trigger a new reconciliation
+		if err := r.updateExternalDNSCleanupStatus(ctx, externalDNS, metav1.Condition{
+			Type:    ExternalDNSRecordsCleanedUpConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "WaitingForDeploymentDeletion",
+			Message: "The cleanup job will be started once the operand deployment is deleted",
+		}); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update externalDNS custom resource %s: %w", externalDNS.Name, err)
+		}
+		return reconcile.Result{}, nil
+	}
+
+	haveServiceAccount, sa, err := r.currentExternalDNSServiceAccount(ctx, types.NamespacedName{Namespace: operandNamespace, Name: controller.ExternalDNSResourceName(externalDNS)})
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get externalDNS service account: %w", err)
+	}
+	credSecretNsName := controller.ExternalDNSDestCredentialsSecretName(operandNamespace, externalDNS.Name)
+	credSecretExists, credSecret, err := r.currentExternalDNSSecret(ctx, credSecretNsName)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to get the target credentials secret: %w", err)
+	}
+	if !credSecretExists && workloadIdentityWithoutSecret(externalDNS) {
+		// the projected service account token replaces the credentials secret
+		credSecret, credSecretExists = &corev1.Secret{}, true
+	}
+	if !haveServiceAccount || !credSecretExists {
+		// the instance was never deployed or its operand resources are gone,
+		// no chance to clean up the records: don't block the deletion
+		r.recordCleanupEvent(externalDNS, metav1.Condition{
+			Type:    ExternalDNSRecordsCleanedUpConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "OperandResourcesNotFound",
+			Message: "The service account or the credentials secret of the operand not found, the DNS records were not cleaned up",
+		})
+		return reconcile.Result{}, r.removeExternalDNSFinalizer(ctx, externalDNS)
+	}
+
+	var trustCAConfigMap *corev1.ConfigMap
+	if r.config.InjectTrustedCA {
+		configMapExists, configMap, err := r.currentExternalDNSTrustedCAConfigMap(ctx, controller.ExternalDNSDestTrustedCAConfigMapName(operandNamespace))
+		if err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to get the target CA configmap: %w", err)
+		}
+		if configMapExists {
+			trustCAConfigMap = configMap
+		}
+	}
+
+	haveJob, job, err := r.ensureExternalDNSCleanupJob(ctx, operandNamespace, r.config.Image, sa, credSecret, trustCAConfigMap, externalDNS)
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to ensure externalDNS cleanup job: %w", err)
+	} else if !haveJob {
+		return reconcile.Result{}, fmt.Errorf("failed to get externalDNS cleanup job")
+	}
+
+	cleanupCond := computeRecordsCleanedUpCondition(job)
+	if finished, _ := cleanupJobFinished(job); finished {
+		r.recordCleanupEvent(externalDNS, cleanupCond)
+		return reconcile.Result{}, r.removeExternalDNSFinalizer(ctx, externalDNS)
+	}
+
+	if err := r.updateExternalDNSCleanupStatus(ctx, externalDNS, cleanupCond); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to update externalDNS custom resource %s: %w", externalDNS.Name, err)
+	}
+	// the completion of the job will trigger a new reconciliation
+	return reconcile.Result{}, nil
+}
+
+// recordCleanupEvent emits the event with the final result of the cleanup of the given externalDNS.
+// The result is not written in the status: the instance is gone once the finalizer is removed.
+func (r *reconciler) recordCleanupEvent(externalDNS *operatorv1beta1.ExternalDNS, cleanupCond metav1.Condition) {
+	if r.recorder == nil {
+		return
+	}
+	eventType := corev1.EventTypeNormal
+	if cleanupCond.Status != metav1.ConditionTrue {
+		eventType = corev1.EventTypeWarning
+	}
+	r.recorder.Event(externalDNS, eventType, cleanupCond.Reason, cleanupCond.Message)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestFinalizeExternalDNS(t *testing.T) {
+	testCases := []struct {
+		name              string
+		dryRun            bool
+		webhookProvider   bool
+		existingObjects   []runtime.Object
+		expectedEvents    []string
+		expectedFinalized bool
+		expectedReason    string
+	}{
+		{
+			name:              "Cleanup job in progress",
+			existingObjects:   []runtime.Object{testServiceAccount(), testSecret(), testRunningCleanupJob()},
+			expectedFinalized: false,
+			expectedReason:    "CleanupInProgress",
+		},
+		{
+			name:              "Cleanup job completed",
+			existingObjects:   []runtime.Object{testServiceAccount(), testSecret(), testCleanupJob(batchv1.JobComplete)},
+			expectedEvents:    []string{"Normal CleanupSucceeded The cleanup job external-dns-test-cleanup completed"},
+			expectedFinalized: true,
+		},
+		{
+			name:              "Cleanup job failed",
+			existingObjects:   []runtime.Object{testServiceAccount(), testSecret(), testCleanupJob(batchv1.JobFailed)},
+			expectedEvents:    []string{"Warning CleanupFailed The cleanup job external-dns-test-cleanup failed, some DNS records may be left behind"},
+			expectedFinalized: true,
+		},
+		{
+			name:              "Operand resources not found",
+			existingObjects:   []runtime.Object{testSecret()},
+			expectedEvents:    []string{"Warning OperandResourcesNotFound The service account or the credentials secret of the operand not found, the DNS records were not cleaned up"},
+			expectedFinalized: true,
+		},
+		{
+			name:              "Dry run",
+			dryRun:            true,
+			existingObjects:   []runtime.Object{testServiceAccount(), testSecret()},
+			expectedEvents:    []string{"Warning DryRun The instance runs in the dry run mode, the DNS records were not cleaned up"},
+			expectedFinalized: true,
+		},
+		{
+			name:              "Webhook provider without native sidecars",
+			webhookProvider:   true,
+			existingObjects:   []runtime.Object{testServiceAccount(), testSecret()},
+			expectedEvents:    []string{"Warning NativeSidecarsNotSupported The cleanup of the webhook provider requires the native sidecars of Kubernetes 1.29 or later, the DNS records were not cleaned up"},
+			expectedFinalized: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testExtDNSInstanceBeingDeleted()
+			extDNS.Spec.DryRun = tc.dryRun
+			if tc.webhookProvider {
+				extDNS.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+					Type:    operatorv1beta1.ProviderTypeWebhook,
+					Webhook: &operatorv1beta1.ExternalDNSWebhookProviderOptions{Image: "quay.io/test/external-dns-webhook:latest"},
+				}
+			}
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithStatusSubresource(&operatorv1beta1.ExternalDNS{}).WithRuntimeObjects(append(tc.existingObjects, extDNS)...).Build()
+			recorder := record.NewFakeRecorder(10)
+
+			r := &reconciler{
+				client:   cl,
+				scheme:   test.Scheme,
+				config:   testConfig(),
+				log:      zap.New(zap.UseDevMode(true)),
+				recorder: recorder,
+			}
+
+			if _, err := r.finalizeExternalDNS(context.TODO(), extDNS); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			close(recorder.Events)
+			var events []string
+			for event := range recorder.Events {
+				events = append(events, event)
+			}
+			if diff := cmp.Diff(tc.expectedEvents, events); diff != "" {
+				t.Errorf("unexpected events (-want +got):\n%s", diff)
+			}
+
+			if tc.dryRun || tc.webhookProvider {
+				jobs := &batchv1.JobList{}
+				if err := cl.List(context.TODO(), jobs); err != nil {
+					t.Fatalf("failed to list jobs: %v", err)
+				}
+				if len(jobs.Items) != 0 {
+					t.Errorf("expected no cleanup job, got %d", len(jobs.Items))
+				}
+			}
+
+			current := &operatorv1beta1.ExternalDNS{}
+			err := cl.Get(context.TODO(), types.NamespacedName{Name: extDNS.Name}, current)
+			if tc.expectedFinalized {
+				// the instance is gone once the finalizer is removed
+				if !errors.IsNotFound(err) {
+					t.Fatalf("expected externalDNS to be deleted, got error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get externalDNS: %v", err)
+			}
+			var reason string
+			for _, cond := range current.Status.Conditions {
+				if cond.Type == ExternalDNSRecordsCleanedUpConditionType {
+					reason = cond.Reason
+				}
+			}
+			if reason != tc.expectedReason {
+				t.Errorf("expected %s condition reason %q, got %q", ExternalDNSRecordsCleanedUpConditionType, tc.expectedReason, reason)
+			}
+		})
+	}
+}
+
+func testRunningCleanupJob() *batchv1.Job {
+	job := testCleanupJob(batchv1.JobComplete)
+	job.Status.Conditions = nil
+	return job
+}
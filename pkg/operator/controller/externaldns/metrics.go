@@ -0,0 +1,310 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+const (
+	// servingCertSecretAnnotation makes the service CA of OpenShift
+	// generate the serving certificate for the service.
+	servingCertSecretAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+	// serviceCAFile is the service CA bundle mounted into the Prometheus pods of OpenShift.
+	serviceCAFile = "/etc/prometheus/configmaps/serving-certs-ca-bundle/service-ca.crt"
+	// serviceAccountTokenFile is the token which Prometheus presents to kube-rbac-proxy.
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	metricsScrapeInterval   = "30s"
+	metricsPath             = "/metrics"
+	// metricsZoneLabel is the label which maps the metrics to the zone of ExternalDNS container.
+	metricsZoneLabel = "zone"
+	// metricsAllZones is the value of the zone label for the container which publishes to all the zones.
+	metricsAllZones = "all"
+)
+
+// serviceMonitorGVK is the kind of Prometheus operator's ServiceMonitor.
+// The unstructured object is used to not depend on Prometheus operator's API.
+var serviceMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// ensureExternalDNSMetrics ensures that the service and the service monitor for the metrics of the given externalDNS
+// exist if the metrics are exposed and don't exist otherwise.
+// The service monitor is skipped if its CRD is not installed in the cluster.
+func (r *reconciler) ensureExternalDNSMetrics(ctx context.Context, namespace string, externalDNS *operatorv1beta1.ExternalDNS) error {
+	nsName := controller.ExternalDNSMetricsServiceName(namespace, externalDNS)
+
+	serviceMonitorSupported, err := r.serviceMonitorSupported()
+	if err != nil {
+		return err
+	}
+
+	if externalDNS.Spec.Metrics.Exposure != operatorv1beta1.MetricsExposureKubeRBACProxy {
+		if serviceMonitorSupported {
+			if err := r.deleteExternalDNSMetricsObject(ctx, nsName, newServiceMonitor(), externalDNS); err != nil {
+				return err
+			}
+		}
+		return r.deleteExternalDNSMetricsObject(ctx, nsName, &corev1.Service{}, externalDNS)
+	}
+
+	if err := r.ensureExternalDNSMetricsService(ctx, nsName, externalDNS); err != nil {
+		return err
+	}
+
+	if serviceMonitorSupported {
+		return r.ensureExternalDNSServiceMonitor(ctx, nsName, externalDNS)
+	}
+	return nil
+}
+
+// serviceMonitorSupported returns true if the ServiceMonitor CRD is installed in the cluster.
+func (r *reconciler) serviceMonitorSupported() (bool, error) {
+	if _, err := r.client.RESTMapper().RESTMapping(serviceMonitorGVK.GroupKind(), serviceMonitorGVK.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get the mapping for %s: %w", serviceMonitorGVK, err)
+	}
+	return true, nil
+}
+
+// ensureExternalDNSMetricsService ensures that the externalDNS metrics service exists and is up to date.
+func (r *reconciler) ensureExternalDNSMetricsService(ctx context.Context, nsName types.NamespacedName, externalDNS *operatorv1beta1.ExternalDNS) error {
+	desired := desiredExternalDNSMetricsService(nsName, externalDNS, r.config.IsOpenShift)
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return fmt.Errorf("failed to set the controller reference for metrics service: %w", err)
+	}
+
+	current := &corev1.Service{}
+	if err := r.client.Get(ctx, nsName, current); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get externalDNS metrics service %s: %w", nsName, err)
+		}
+		if err := r.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create externalDNS metrics service %s: %w", nsName, err)
+		}
+		r.log.Info("created externalDNS metrics service", "namespace", nsName.Namespace, "name", nsName.Name)
+		return nil
+	}
+
+	// the cluster IP and other fields defaulted by API are preserved
+	if equality.Semantic.DeepEqual(current.Spec.Ports, desired.Spec.Ports) &&
+		reflect.DeepEqual(current.Spec.Selector, desired.Spec.Selector) &&
+		reflect.DeepEqual(current.Labels, desired.Labels) &&
+		reflect.DeepEqual(current.Annotations, desired.Annotations) {
+		return nil
+	}
+	updated := current.DeepCopy()
+	updated.Labels = desired.Labels
+	updated.Annotations = desired.Annotations
+	updated.Spec.Ports = desired.Spec.Ports
+	updated.Spec.Selector = desired.Spec.Selector
+	if err := r.client.Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update externalDNS metrics service %s: %w", nsName, err)
+	}
+	r.log.Info("updated externalDNS metrics service", "namespace", nsName.Namespace, "name", nsName.Name)
+	return nil
+}
+
+// ensureExternalDNSServiceMonitor ensures that the externalDNS service monitor exists and is up to date.
+func (r *reconciler) ensureExternalDNSServiceMonitor(ctx context.Context, nsName types.NamespacedName, externalDNS *operatorv1beta1.ExternalDNS) error {
+	desired := desiredExternalDNSServiceMonitor(nsName, externalDNS, r.config.IsOpenShift)
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return fmt.Errorf("failed to set the controller reference for service monitor: %w", err)
+	}
+
+	current := newServiceMonitor()
+	if err := r.client.Get(ctx, nsName, current); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get externalDNS service monitor %s: %w", nsName, err)
+		}
+		if err := r.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create externalDNS service monitor %s: %w", nsName, err)
+		}
+		r.log.Info("created externalDNS service monitor", "namespace", nsName.Namespace, "name", nsName.Name)
+		return nil
+	}
+
+	if equality.Semantic.DeepEqual(current.Object["spec"], desired.Object["spec"]) &&
+		reflect.DeepEqual(current.GetLabels(), desired.GetLabels()) {
+		return nil
+	}
+	updated := current.DeepCopy()
+	updated.SetLabels(desired.GetLabels())
+	updated.Object["spec"] = desired.Object["spec"]
+	if err := r.client.Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update externalDNS service monitor %s: %w", nsName, err)
+	}
+	r.log.Info("updated externalDNS service monitor", "namespace", nsName.Namespace, "name", nsName.Name)
+	return nil
+}
+
+// deleteExternalDNSMetricsObject deletes the given metrics object if it exists and is controlled by the given externalDNS.
+func (r *reconciler) deleteExternalDNSMetricsObject(ctx context.Context, nsName types.NamespacedName, obj client.Object, externalDNS *operatorv1beta1.ExternalDNS) error {
+	if err := r.client.Get(ctx, nsName, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get externalDNS metrics object %s: %w", nsName, err)
+	}
+	if !metav1.IsControlledBy(obj, externalDNS) {
+		return nil
+	}
+	if err := r.client.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete externalDNS metrics object %s: %w", nsName, err)
+	}
+	r.log.Info("deleted externalDNS metrics object", "namespace", nsName.Namespace, "name", nsName.Name, "kind", obj.GetObjectKind().GroupVersionKind().Kind)
+	return nil
+}
+
+// desiredExternalDNSMetricsService returns the desired metrics service resource.
+// The service has a port per ExternalDNS container.
+func desiredExternalDNSMetricsService(nsName types.NamespacedName, externalDNS *operatorv1beta1.ExternalDNS, isOpenShift bool) *corev1.Service {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsName.Name,
+			Namespace: nsName.Namespace,
+			Labels:    externalDNSMetricsLabels(externalDNS),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				appNameLabel:     controller.ExternalDNSBaseName,
+				appInstanceLabel: externalDNS.Name,
+			},
+		},
+	}
+	if isOpenShift {
+		svc.Annotations = map[string]string{
+			servingCertSecretAnnotation: nsName.Name,
+		}
+	}
+	for seq := range externalDNSMetricsZones(externalDNS) {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       metricsPortName(seq),
+			Port:       int32(metricsProxyStartPort + seq),
+			TargetPort: intstr.FromString(metricsPortName(seq)),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+	return svc
+}
+
+// desiredExternalDNSServiceMonitor returns the desired service monitor resource.
+// The service monitor has an endpoint per port of the metrics service,
+// the endpoint labels the metrics with the zone of the corresponding ExternalDNS container.
+func desiredExternalDNSServiceMonitor(nsName types.NamespacedName, externalDNS *operatorv1beta1.ExternalDNS, isOpenShift bool) *unstructured.Unstructured {
+	tlsConfig := map[string]interface{}{
+		// kube-rbac-proxy uses a self-signed certificate outside of OpenShift
+		"insecureSkipVerify": true,
+	}
+	if isOpenShift {
+		tlsConfig = map[string]interface{}{
+			"caFile":     serviceCAFile,
+			"serverName": fmt.Sprintf("%s.%s.svc", nsName.Name, nsName.Namespace),
+		}
+	}
+
+	endpoints := []interface{}{}
+	for seq, zone := range externalDNSMetricsZones(externalDNS) {
+		if zone == "" {
+			zone = metricsAllZones
+		}
+		endpoints = append(endpoints, map[string]interface{}{
+			"port":            metricsPortName(seq),
+			"path":            metricsPath,
+			"scheme":          "https",
+			"interval":        metricsScrapeInterval,
+			"bearerTokenFile": serviceAccountTokenFile,
+			"tlsConfig":       tlsConfig,
+			"relabelings": []interface{}{
+				map[string]interface{}{
+					"action":      "replace",
+					"targetLabel": metricsZoneLabel,
+					"replacement": zone,
+				},
+			},
+		})
+	}
+
+	matchLabels := map[string]interface{}{}
+	for k, v := range externalDNSMetricsLabels(externalDNS) {
+		matchLabels[k] = v
+	}
+
+	sm := newServiceMonitor()
+	sm.SetName(nsName.Name)
+	sm.SetNamespace(nsName.Namespace)
+	sm.SetLabels(externalDNSMetricsLabels(externalDNS))
+	sm.Object["spec"] = map[string]interface{}{
+		"endpoints": endpoints,
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []interface{}{nsName.Namespace},
+		},
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+	}
+	return sm
+}
+
+// newServiceMonitor returns an empty unstructured service monitor.
+func newServiceMonitor() *unstructured.Unstructured {
+	sm := &unstructured.Unstructured{}
+	sm.SetGroupVersionKind(serviceMonitorGVK)
+	return sm
+}
+
+// externalDNSMetricsLabels returns the labels of the metrics service and service monitor.
+func externalDNSMetricsLabels(externalDNS *operatorv1beta1.ExternalDNS) map[string]string {
+	labels := controller.ExternalDNSOperandLabels()
+	labels[appNameLabel] = controller.ExternalDNSBaseName
+	labels[appInstanceLabel] = externalDNS.Name
+	return labels
+}
+
+// externalDNSMetricsZones returns the zones of ExternalDNS containers in the order of their metrics ports.
+// The empty zone stands for the container which publishes to all the zones.
+func externalDNSMetricsZones(externalDNS *operatorv1beta1.ExternalDNS) []string {
+	if len(externalDNS.Spec.Zones) > 0 {
+		return externalDNS.Spec.Zones
+	}
+	// Azure publishes to the public and private zones from the separate containers
+	if externalDNS.Spec.Provider.Type == operatorv1beta1.ProviderTypeAzure {
+		return []string{"", ""}
+	}
+	return []string{""}
+}
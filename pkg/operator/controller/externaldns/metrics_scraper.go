@@ -0,0 +1,94 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+)
+
+const (
+	// serviceCABundleFile is the service CA bundle which OpenShift mounts into every pod
+	// next to the service account token.
+	serviceCABundleFile = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+	// scrapeMetricsTimeout is the timeout of a single scrape of the metrics.
+	scrapeMetricsTimeout = 10 * time.Second
+	// maxMetricsResponseSize is the maximum size of the scraped metrics.
+	maxMetricsResponseSize = 4 << 20
+)
+
+// scrapeMetricsFunc returns the metrics exposed on the given URL in the Prometheus text format.
+// The given server name is used to verify the serving certificate.
+type scrapeMetricsFunc func(ctx context.Context, url, serverName string) (string, error)
+
+// newScrapeMetricsFunc returns the function which scrapes the metrics exposed by kube-rbac-proxy
+// with the bearer token of the given config.
+// The serving certificate is verified against the service CA of OpenShift.
+func newScrapeMetricsFunc(restConfig *rest.Config) scrapeMetricsFunc {
+	return func(ctx context.Context, url, serverName string) (string, error) {
+		caBundle, err := os.ReadFile(serviceCABundleFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the service CA bundle: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return "", fmt.Errorf("no certificates found in the service CA bundle %s", serviceCABundleFile)
+		}
+		tlsConfig := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: serverName,
+			RootCAs:    rootCAs,
+		}
+
+		rt, err := transport.NewBearerAuthWithRefreshRoundTripper(restConfig.BearerToken, restConfig.BearerTokenFile, &http.Transport{
+			TLSClientConfig:   tlsConfig,
+			DisableKeepAlives: true,
+		})
+		if err != nil {
+			return "", err
+		}
+		httpClient := &http.Client{Transport: rt, Timeout: scrapeMetricsTimeout}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("unexpected response status %q", resp.Status)
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxMetricsResponseSize))
+		if err != nil {
+			return "", err
+		}
+		return string(body), nil
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestDesiredExternalDNSMetricsService(t *testing.T) {
+	testCases := []struct {
+		name                string
+		inputExternalDNS    *operatorv1beta1.ExternalDNS
+		inputIsOpenShift    bool
+		expectedPorts       []corev1.ServicePort
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:             "Port per zone",
+			inputExternalDNS: testAWSExternalDNSZones([]string{test.PublicZone, test.PrivateZone}, operatorv1beta1.SourceTypeService),
+			expectedPorts: []corev1.ServicePort{
+				{Name: "metrics-0", Port: 8443, TargetPort: intstr.FromString("metrics-0"), Protocol: corev1.ProtocolTCP},
+				{Name: "metrics-1", Port: 8444, TargetPort: intstr.FromString("metrics-1"), Protocol: corev1.ProtocolTCP},
+			},
+		},
+		{
+			name:             "Azure without zones on OpenShift",
+			inputExternalDNS: testAzureExternalDNSNoZones(operatorv1beta1.SourceTypeService),
+			inputIsOpenShift: true,
+			expectedPorts: []corev1.ServicePort{
+				{Name: "metrics-0", Port: 8443, TargetPort: intstr.FromString("metrics-0"), Protocol: corev1.ProtocolTCP},
+				{Name: "metrics-1", Port: 8444, TargetPort: intstr.FromString("metrics-1"), Protocol: corev1.ProtocolTCP},
+			},
+			expectedAnnotations: map[string]string{
+				servingCertSecretAnnotation: test.OperandName + "-metrics",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nsName := controller.ExternalDNSMetricsServiceName(test.OperandNamespace, tc.inputExternalDNS)
+			svc := desiredExternalDNSMetricsService(nsName, tc.inputExternalDNS, tc.inputIsOpenShift)
+			if diff := cmp.Diff(tc.expectedPorts, svc.Spec.Ports); diff != "" {
+				t.Errorf("unexpected service ports (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedAnnotations, svc.Annotations); diff != "" {
+				t.Errorf("unexpected service annotations (-want +got):\n%s", diff)
+			}
+			expectedSelector := map[string]string{
+				appNameLabel:     controller.ExternalDNSBaseName,
+				appInstanceLabel: test.Name,
+			}
+			if diff := cmp.Diff(expectedSelector, svc.Spec.Selector); diff != "" {
+				t.Errorf("unexpected service selector (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDesiredExternalDNSServiceMonitor(t *testing.T) {
+	extDNS := testAWSExternalDNSZones([]string{test.PublicZone, test.PrivateZone}, operatorv1beta1.SourceTypeService)
+	nsName := controller.ExternalDNSMetricsServiceName(test.OperandNamespace, extDNS)
+
+	sm := desiredExternalDNSServiceMonitor(nsName, extDNS, true)
+
+	endpoints, found, err := unstructured.NestedSlice(sm.Object, "spec", "endpoints")
+	if err != nil || !found {
+		t.Fatalf("failed to get service monitor endpoints: %v", err)
+	}
+	gotZones := map[string]string{}
+	for _, e := range endpoints {
+		endpoint := e.(map[string]interface{})
+		if endpoint["scheme"] != "https" {
+			t.Errorf("expected https scheme, got %v", endpoint["scheme"])
+		}
+		tlsConfig := endpoint["tlsConfig"].(map[string]interface{})
+		if tlsConfig["serverName"] != test.OperandName+"-metrics."+test.OperandNamespace+".svc" {
+			t.Errorf("unexpected server name %v", tlsConfig["serverName"])
+		}
+		relabeling := endpoint["relabelings"].([]interface{})[0].(map[string]interface{})
+		if relabeling["targetLabel"] != metricsZoneLabel {
+			t.Errorf("unexpected relabeling target label %v", relabeling["targetLabel"])
+		}
+		gotZones[endpoint["port"].(string)] = relabeling["replacement"].(string)
+	}
+	expectedZones := map[string]string{
+		"metrics-0": test.PublicZone,
+		"metrics-1": test.PrivateZone,
+	}
+	if diff := cmp.Diff(expectedZones, gotZones); diff != "" {
+		t.Errorf("unexpected zones of service monitor endpoints (-want +got):\n%s", diff)
+	}
+}
+
+func TestBuildMetricsProxy(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		inputIsOpenShift     bool
+		expectedArgs         []string
+		expectedVolumeMounts []corev1.VolumeMount
+	}{
+		{
+			name: "Self-signed certificate",
+			expectedArgs: []string{
+				"--secure-listen-address=0.0.0.0:8444",
+				"--upstream=http://127.0.0.1:7980/",
+				"--logtostderr=true",
+				"--http2-disable",
+			},
+		},
+		{
+			name:             "Service CA certificate on OpenShift",
+			inputIsOpenShift: true,
+			expectedArgs: []string{
+				"--secure-listen-address=0.0.0.0:8444",
+				"--upstream=http://127.0.0.1:7980/",
+				"--logtostderr=true",
+				"--http2-disable",
+				"--tls-cert-file=/etc/tls/private/tls.crt",
+				"--tls-private-key-file=/etc/tls/private/tls.key",
+			},
+			expectedVolumeMounts: []corev1.VolumeMount{
+				{Name: metricsCertVolumeName, MountPath: metricsCertMountPath, ReadOnly: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := &externalDNSContainerBuilder{
+				image:             test.OperandImage,
+				metricsProxyImage: test.MetricsProxyImage,
+				isOpenShift:       tc.inputIsOpenShift,
+				externalDNS:       testAWSExternalDNS(operatorv1beta1.SourceTypeService),
+			}
+			container := b.buildMetricsProxy(1)
+			if container.Name != "kube-rbac-proxy-1" || container.Image != test.MetricsProxyImage {
+				t.Errorf("unexpected container %q with image %q", container.Name, container.Image)
+			}
+			if diff := cmp.Diff(tc.expectedArgs, container.Args); diff != "" {
+				t.Errorf("unexpected container args (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedVolumeMounts, container.VolumeMounts); diff != "" {
+				t.Errorf("unexpected container volume mounts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnsureExternalDNSMetrics(t *testing.T) {
+	exposed := testAWSExternalDNSMetricsExposure(operatorv1beta1.SourceTypeService, operatorv1beta1.MetricsExposureKubeRBACProxy)
+	local := testAWSExternalDNSMetricsExposure(operatorv1beta1.SourceTypeService, operatorv1beta1.MetricsExposureLocal)
+	nsName := controller.ExternalDNSMetricsServiceName(test.OperandNamespace, exposed)
+
+	ownedService := desiredExternalDNSMetricsService(nsName, local, false)
+	if err := controllerutil.SetControllerReference(local, ownedService, test.Scheme); err != nil {
+		t.Fatalf("failed to set controller reference: %v", err)
+	}
+	ownedServiceMonitor := desiredExternalDNSServiceMonitor(nsName, local, false)
+	if err := controllerutil.SetControllerReference(local, ownedServiceMonitor, test.Scheme); err != nil {
+		t.Fatalf("failed to set controller reference: %v", err)
+	}
+	foreignService := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: nsName.Name, Namespace: nsName.Namespace}}
+
+	testCases := []struct {
+		name                   string
+		inputExternalDNS       *operatorv1beta1.ExternalDNS
+		serviceMonitorCRD      bool
+		existingObjects        []client.Object
+		expectedService        bool
+		expectedServiceMonitor bool
+	}{
+		{
+			name:                   "Exposed metrics",
+			inputExternalDNS:       exposed,
+			serviceMonitorCRD:      true,
+			expectedService:        true,
+			expectedServiceMonitor: true,
+		},
+		{
+			name:             "Exposed metrics without ServiceMonitor CRD",
+			inputExternalDNS: exposed,
+			expectedService:  true,
+		},
+		{
+			name:              "Local metrics remove the service and the service monitor",
+			inputExternalDNS:  local,
+			serviceMonitorCRD: true,
+			existingObjects:   []client.Object{ownedService, ownedServiceMonitor},
+		},
+		{
+			name:             "Local metrics keep the service not owned by the instance",
+			inputExternalDNS: local,
+			existingObjects:  []client.Object{foreignService},
+			expectedService:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mapper := meta.NewDefaultRESTMapper(nil)
+			if tc.serviceMonitorCRD {
+				mapper.Add(serviceMonitorGVK, meta.RESTScopeNamespace)
+			}
+			objs := []client.Object{}
+			for _, o := range tc.existingObjects {
+				objs = append(objs, o.DeepCopyObject().(client.Object))
+			}
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRESTMapper(mapper).WithObjects(objs...).Build()
+			r := &reconciler{
+				client: cl,
+				scheme: test.Scheme,
+				config: testConfig(),
+				log:    zap.New(zap.UseDevMode(true)),
+			}
+
+			if err := r.ensureExternalDNSMetrics(context.TODO(), test.OperandNamespace, tc.inputExternalDNS); err != nil {
+				t.Fatalf("expected no error from calling ensureExternalDNSMetrics, but received %v", err)
+			}
+
+			err := cl.Get(context.TODO(), nsName, &corev1.Service{})
+			if tc.expectedService && err != nil {
+				t.Errorf("expected metrics service to exist, got %v", err)
+			} else if !tc.expectedService && !errors.IsNotFound(err) {
+				t.Errorf("expected metrics service to be absent, got %v", err)
+			}
+
+			if !tc.serviceMonitorCRD {
+				return
+			}
+			err = cl.Get(context.TODO(), nsName, newServiceMonitor())
+			if tc.expectedServiceMonitor && err != nil {
+				t.Errorf("expected service monitor to exist, got %v", err)
+			} else if !tc.expectedServiceMonitor && !errors.IsNotFound(err) {
+				t.Errorf("expected service monitor to be absent, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+const (
+	// recordsUpToDateLogMessage is logged by ExternalDNS when a synchronization has no changes to apply.
+	recordsUpToDateLogMessage = "All records are already up to date"
+	// ownerMigrationCompletedReason is the reason of the condition which reports the completed owner migration.
+	ownerMigrationCompletedReason = "MigrationCompleted"
+)
+
+// txtMigrateFromOwnerID returns the owner ID of the TXT records which the given ExternalDNS takes over.
+// Returns an empty string if no migration is configured.
+func txtMigrateFromOwnerID(externalDNS *operatorv1beta1.ExternalDNS) string {
+	registry := externalDNS.Spec.Registry
+	if registry.Type == operatorv1beta1.RegistryTypeNoop || registry.TXT == nil {
+		return ""
+	}
+	return registry.TXT.MigrateFromOwnerID
+}
+
+// migrateFromTXTOwnerArg returns the ExternalDNS argument which migrates the TXT records from the given owner ID.
+func migrateFromTXTOwnerArg(ownerID string) string {
+	return fmt.Sprintf("--migrate-from-txt-owner=%s", ownerID)
+}
+
+// computeOwnerMigrationCondition returns an externalDNS condition which reports the progress
+// of the migration of the TXT records from the previous owner ID.
+// A zone is done with the migration once its container runs with the migration argument
+// and a synchronization found no changes to apply: all the TXT records of the previous owner were rewritten.
+// The completion is kept until the spec changes: the message of the synchronization
+// is pushed out of the tail of the logs by the next synchronizations.
+func (r *reconciler) computeOwnerMigrationCondition(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, deployment *appsv1.Deployment) metav1.Condition {
+	from := txtMigrateFromOwnerID(externalDNS)
+	if from == "" {
+		return metav1.Condition{
+			Type:    ExternalDNSOwnerMigrationConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "NoMigration",
+			Message: "No TXT owner migration is configured",
+		}
+	}
+
+	if ownerMigrationCompleted(externalDNS) {
+		return ownerMigrationCompletedCondition(from)
+	}
+
+	pods, err := runningExternalDNSPods(ctx, r.client, deployment)
+	if err != nil {
+		return metav1.Condition{
+			Type:    ExternalDNSOwnerMigrationConditionType,
+			Status:  metav1.ConditionUnknown,
+			Reason:  "MigrationUnknown",
+			Message: "Unable to list pods: " + err.Error(),
+		}
+	}
+	if len(pods) == 0 {
+		return metav1.Condition{
+			Type:    ExternalDNSOwnerMigrationConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "MigrationPending",
+			Message: fmt.Sprintf("Waiting for a running ExternalDNS pod to migrate the TXT records from owner %q", from),
+		}
+	}
+
+	pod := &pods[0]
+	migrateArg := migrateFromTXTOwnerArg(from)
+	total, migrated := 0, 0
+	for _, zone := range externalDNSMetricsZones(externalDNS) {
+		total++
+		containerName := controller.ExternalDNSContainerName(zone)
+		var container *corev1.Container
+		for i := range pod.Spec.Containers {
+			if pod.Spec.Containers[i].Name == containerName {
+				container = &pod.Spec.Containers[i]
+				break
+			}
+		}
+		if container == nil || !slices.Contains(container.Args, migrateArg) {
+			return metav1.Condition{
+				Type:    ExternalDNSOwnerMigrationConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "MigrationPending",
+				Message: fmt.Sprintf("Waiting for the rollout of the migration of the TXT records from owner %q, the pod %s runs without it", from, pod.Name),
+			}
+		}
+		if r.podLogs == nil {
+			continue
+		}
+		// the logs are the best effort source:
+		// the zone is not considered as migrated if they cannot be read
+		if logs, err := r.podLogs(ctx, pod.Namespace, pod.Name, container.Name, ownerMigrationLogsTailLines); err == nil && strings.Contains(logs, recordsUpToDateLogMessage) {
+			migrated++
+		}
+	}
+
+	if migrated < total {
+		return metav1.Condition{
+			Type:    ExternalDNSOwnerMigrationConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "MigrationInProgress",
+			Message: fmt.Sprintf("The TXT records are being migrated from owner %q: %d/%d of containers synchronized the records", from, migrated, total),
+		}
+	}
+
+	return ownerMigrationCompletedCondition(from)
+}
+
+// ownerMigrationCompleted returns true if the given ExternalDNS reported the completion
+// of the owner migration for its current generation.
+func ownerMigrationCompleted(externalDNS *operatorv1beta1.ExternalDNS) bool {
+	if externalDNS.Status.ObservedGeneration != externalDNS.Generation {
+		return false
+	}
+	for _, cond := range externalDNS.Status.Conditions {
+		if cond.Type == ExternalDNSOwnerMigrationConditionType {
+			return cond.Reason == ownerMigrationCompletedReason
+		}
+	}
+	return false
+}
+
+// ownerMigrationCompletedCondition returns the condition which reports the completion
+// of the migration of the TXT records from the given owner ID.
+func ownerMigrationCompletedCondition(from string) metav1.Condition {
+	return metav1.Condition{
+		Type:    ExternalDNSOwnerMigrationConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  ownerMigrationCompletedReason,
+		Message: fmt.Sprintf("The TXT records were migrated from owner %q, the migration can be removed from the registry options", from),
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestComputeOwnerMigrationCondition(t *testing.T) {
+	const fromOwner = "external-dns-old-name"
+	migratingPod := func(args ...string) *corev1.Pod {
+		pod := testZonePod("pod", corev1.PodRunning)
+		pod.Spec.Containers = []corev1.Container{
+			{Name: controller.ExternalDNSContainerName(test.PublicZone), Args: args},
+			{Name: metricsProxyContainerName(0)},
+		}
+		return pod
+	}
+
+	completedStatus := operatorv1beta1.ExternalDNSStatus{
+		ObservedGeneration: 1,
+		Conditions: []metav1.Condition{
+			{
+				Type:   ExternalDNSOwnerMigrationConditionType,
+				Status: metav1.ConditionFalse,
+				Reason: "MigrationCompleted",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		inputFromOwner    string
+		inputGeneration   int64
+		inputStatus       operatorv1beta1.ExternalDNSStatus
+		existingPods      []runtime.Object
+		podLogs           podLogsFunc
+		expectedCondition metav1.Condition
+	}{
+		{
+			name: "No migration",
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSOwnerMigrationConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "NoMigration",
+				Message: "No TXT owner migration is configured",
+			},
+		},
+		{
+			name:           "No running pod",
+			inputFromOwner: fromOwner,
+			existingPods:   []runtime.Object{testZonePod("pod", corev1.PodPending)},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSOwnerMigrationConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "MigrationPending",
+				Message: `Waiting for a running ExternalDNS pod to migrate the TXT records from owner "external-dns-old-name"`,
+			},
+		},
+		{
+			name:           "Pod without migration argument",
+			inputFromOwner: fromOwner,
+			existingPods:   []runtime.Object{migratingPod("--txt-owner-id=external-dns-test")},
+			podLogs:        fakePodLogs(recordsUpToDateLogMessage, nil),
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSOwnerMigrationConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "MigrationPending",
+				Message: `Waiting for the rollout of the migration of the TXT records from owner "external-dns-old-name", the pod pod runs without it`,
+			},
+		},
+		{
+			name:           "Records being rewritten",
+			inputFromOwner: fromOwner,
+			existingPods:   []runtime.Object{migratingPod("--txt-owner-id=external-dns-test", "--migrate-from-txt-owner="+fromOwner)},
+			podLogs:        fakePodLogs(`level=info msg="Desired change: UPSERT external-dns-foo.example.com TXT"`, nil),
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSOwnerMigrationConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "MigrationInProgress",
+				Message: `The TXT records are being migrated from owner "external-dns-old-name": 0/1 of containers synchronized the records`,
+			},
+		},
+		{
+			name:           "Records rewritten",
+			inputFromOwner: fromOwner,
+			existingPods:   []runtime.Object{migratingPod("--txt-owner-id=external-dns-test", "--migrate-from-txt-owner="+fromOwner)},
+			podLogs:        fakePodLogs(`level=info msg="`+recordsUpToDateLogMessage+`"`, nil),
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSOwnerMigrationConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "MigrationCompleted",
+				Message: `The TXT records were migrated from owner "external-dns-old-name", the migration can be removed from the registry options`,
+			},
+		},
+		{
+			name:            "Completed migration with the message out of the logs",
+			inputFromOwner:  fromOwner,
+			inputGeneration: 1,
+			inputStatus:     completedStatus,
+			existingPods:    []runtime.Object{migratingPod("--txt-owner-id=external-dns-test", "--migrate-from-txt-owner="+fromOwner)},
+			podLogs:         fakePodLogs(`level=info msg="Desired change: CREATE foo.example.com A"`, nil),
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSOwnerMigrationConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "MigrationCompleted",
+				Message: `The TXT records were migrated from owner "external-dns-old-name", the migration can be removed from the registry options`,
+			},
+		},
+		{
+			name:            "Completed migration of previous generation",
+			inputFromOwner:  fromOwner,
+			inputGeneration: 2,
+			inputStatus:     completedStatus,
+			existingPods:    []runtime.Object{migratingPod("--txt-owner-id=external-dns-test", "--migrate-from-txt-owner="+fromOwner)},
+			podLogs:         fakePodLogs(`level=info msg="Desired change: CREATE foo.example.com A"`, nil),
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSOwnerMigrationConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "MigrationInProgress",
+				Message: `The TXT records are being migrated from owner "external-dns-old-name": 0/1 of containers synchronized the records`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNSZones([]string{test.PublicZone}, operatorv1beta1.SourceTypeService)
+			extDNS.Generation = tc.inputGeneration
+			extDNS.Status = tc.inputStatus
+			if tc.inputFromOwner != "" {
+				extDNS.Spec.Registry.TXT = &operatorv1beta1.ExternalDNSTXTRegistryOptions{MigrateFromOwnerID: tc.inputFromOwner}
+			}
+
+			deployment := fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator")
+			deployment.Namespace = test.OperandNamespace
+
+			r := &reconciler{
+				client:  fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(tc.existingPods...).Build(),
+				scheme:  test.Scheme,
+				config:  testConfig(),
+				log:     zap.New(zap.UseDevMode(true)),
+				podLogs: tc.podLogs,
+			}
+
+			cond := r.computeOwnerMigrationCondition(context.TODO(), extDNS, &deployment)
+			if diff := cmp.Diff(tc.expectedCondition, cond); diff != "" {
+				t.Errorf("unexpected condition (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
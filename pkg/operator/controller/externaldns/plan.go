@@ -0,0 +1,323 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+const (
+	planChangesKey   = "changes"
+	planSummaryKey   = "summary"
+	planActionCreate = "create"
+	planActionUpdate = "update"
+	planActionDelete = "delete"
+	// planUnknownZone is used for the changes logged without the zone
+	// by ExternalDNS which publishes to all the zones
+	planUnknownZone = "unknown"
+)
+
+// plannedChangeParsers extract the planned changes from the log lines of ExternalDNS.
+// ExternalDNS doesn't expose the plan in a structured way,
+// the providers log the changes they would apply in the dry run mode in their own formats.
+var plannedChangeParsers = []func(line string) (plannedChange, bool){
+	// AWS: Desired change: CREATE foo.example.com A [Id: /hostedzone/Z3URY6TWQ91KXX]
+	regexpChangeParser(regexp.MustCompile(`Desired change: (CREATE|UPSERT|DELETE) (\S+) (\S+) \[Id: ([^\]]+)\]`), 1, 2, 3, 4),
+	// Azure, Infoblox: Would create A record named 'foo' to '1.2.3.4' for Azure DNS zone 'example.com'.
+	regexpChangeParser(regexp.MustCompile(`Would (create|update|delete) (\S+) record named '([^']*)'.*zone '([^']*)'`), 1, 3, 2, 4),
+	// Cloudflare: msg="Changing record." action=CREATE record=foo.example.com ttl=1 type=A zone=023e105f4ecef8ad9ca31a8372d0c353
+	regexpChangeParser(regexp.MustCompile(`Changing record\..*action=(\S+).*record=(\S+).*type=(\S+).*zone=(\S+)`), 1, 2, 3, 4),
+	// GCP: Add records: foo.example.com. A [1.2.3.4] 300
+	regexpChangeParser(regexp.MustCompile(`(Add|Del) records: (\S+) (\S+)`), 1, 2, 3, 0),
+}
+
+// plannedChangeActions maps the actions logged by ExternalDNS providers to the actions listed in the plan.
+var plannedChangeActions = map[string]string{
+	"create": planActionCreate,
+	"add":    planActionCreate,
+	"upsert": planActionUpdate,
+	"update": planActionUpdate,
+	"delete": planActionDelete,
+	"del":    planActionDelete,
+}
+
+// plannedChange is a change to a DNS record which ExternalDNS would apply.
+type plannedChange struct {
+	action     string
+	name       string
+	recordType string
+	zone       string
+}
+
+// String returns the representation of the change used in the plan configmap.
+func (c plannedChange) String() string {
+	return fmt.Sprintf("%s %s %s zone=%s", c.action, c.name, c.recordType, c.zone)
+}
+
+// regexpChangeParser returns the parser which extracts the change from the submatches of the given regexp.
+// The zone index can be zero if the zone is not logged.
+func regexpChangeParser(re *regexp.Regexp, actionIdx, nameIdx, typeIdx, zoneIdx int) func(string) (plannedChange, bool) {
+	return func(line string) (plannedChange, bool) {
+		m := re.FindStringSubmatch(line)
+		if m == nil {
+			return plannedChange{}, false
+		}
+		action, ok := plannedChangeActions[strings.ToLower(m[actionIdx])]
+		if !ok {
+			return plannedChange{}, false
+		}
+		change := plannedChange{
+			action:     action,
+			name:       m[nameIdx],
+			recordType: m[typeIdx],
+		}
+		if zoneIdx > 0 {
+			change.zone = m[zoneIdx]
+		}
+		return change, true
+	}
+}
+
+// parsePlannedChanges returns the unique changes found in the given logs.
+// The given default zone is used for the changes logged without the zone.
+func parsePlannedChanges(logs, defaultZone string) []plannedChange {
+	seen := map[plannedChange]bool{}
+	changes := []plannedChange{}
+	for _, line := range strings.Split(logs, "\n") {
+		line = normalizeLogLine(line)
+		for _, parse := range plannedChangeParsers {
+			change, ok := parse(line)
+			if !ok {
+				continue
+			}
+			if change.zone == "" {
+				change.zone = defaultZone
+			}
+			// dry run doesn't apply the changes:
+			// the same changes are logged on every synchronization
+			if !seen[change] {
+				seen[change] = true
+				changes = append(changes, change)
+			}
+			break
+		}
+	}
+	return changes
+}
+
+// ensureExternalDNSDryRunPlan ensures that the configmap with the changes planned by the given externalDNS
+// exists if the dry run mode is enabled and doesn't exist otherwise.
+// Returns the condition which summarizes the plan, and an error when relevant.
+func (r *reconciler) ensureExternalDNSDryRunPlan(ctx context.Context, namespace string, externalDNS *operatorv1beta1.ExternalDNS, deployment *appsv1.Deployment) (metav1.Condition, error) {
+	nsName := controller.ExternalDNSPlanConfigMapName(namespace, externalDNS)
+
+	exist, current, err := r.currentExternalDNSPlanConfigMap(ctx, nsName)
+	if err != nil {
+		return metav1.Condition{}, fmt.Errorf("failed to get externalDNS plan configmap: %w", err)
+	}
+
+	if !externalDNS.Spec.DryRun {
+		if exist {
+			if err := r.client.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+				return metav1.Condition{}, fmt.Errorf("failed to delete externalDNS plan configmap %s: %w", nsName, err)
+			}
+			r.log.Info("deleted externalDNS plan configmap", "namespace", nsName.Namespace, "name", nsName.Name)
+		}
+		return metav1.Condition{
+			Type:    ExternalDNSDryRunConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "DryRunDisabled",
+			Message: "The changes to the DNS records are applied",
+		}, nil
+	}
+
+	changes, err := r.collectPlannedChanges(ctx, externalDNS, deployment)
+	if err != nil {
+		return metav1.Condition{}, err
+	}
+
+	desired := desiredExternalDNSPlanConfigMap(nsName, changes)
+	if err := controllerutil.SetControllerReference(externalDNS, desired, r.scheme); err != nil {
+		return metav1.Condition{}, fmt.Errorf("failed to set the controller reference for plan configmap: %w", err)
+	}
+
+	if !exist {
+		if err := r.client.Create(ctx, desired); err != nil {
+			return metav1.Condition{}, fmt.Errorf("failed to create externalDNS plan configmap %s: %w", nsName, err)
+		}
+		r.log.Info("created externalDNS plan configmap", "namespace", nsName.Namespace, "name", nsName.Name)
+	} else if !reflect.DeepEqual(current.Data, desired.Data) {
+		updated := current.DeepCopy()
+		updated.Data = desired.Data
+		if err := r.client.Update(ctx, updated); err != nil {
+			return metav1.Condition{}, fmt.Errorf("failed to update externalDNS plan configmap %s: %w", nsName, err)
+		}
+		r.log.Info("updated externalDNS plan configmap", "namespace", nsName.Namespace, "name", nsName.Name)
+	}
+
+	return computeDryRunCondition(nsName, changes), nil
+}
+
+// currentExternalDNSPlanConfigMap gets the current externalDNS plan configmap resource.
+func (r *reconciler) currentExternalDNSPlanConfigMap(ctx context.Context, nsName types.NamespacedName) (bool, *corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, nsName, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+	return true, cm, nil
+}
+
+// collectPlannedChanges returns the changes found in the logs of ExternalDNS containers of the given deployment.
+func (r *reconciler) collectPlannedChanges(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, deployment *appsv1.Deployment) ([]plannedChange, error) {
+	changes := []plannedChange{}
+	if deployment == nil || r.podLogs == nil {
+		return changes, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the selector of externalDNS deployment: %w", err)
+	}
+	pods, err := getFilteredPodsList(ctx, r.client, deployment.Namespace, selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list externalDNS pods: %w", err)
+	}
+
+	// every container is dedicated to a zone if the zones are given
+	containerZones := map[string]string{}
+	for _, zone := range externalDNS.Spec.Zones {
+		containerZones[controller.ExternalDNSContainerName(zone)] = zone
+	}
+
+	seen := map[plannedChange]bool{}
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.State.Running == nil || cs.Name == webhookProviderContainerName || isMetricsProxyContainer(cs.Name) {
+				continue
+			}
+			logs, err := r.podLogs(ctx, pod.Namespace, pod.Name, cs.Name, dryRunPlanLogsTailLines)
+			if err != nil {
+				// the plan is the best effort: the logs of other containers may still be read
+				r.log.Error(err, "failed to read externalDNS container logs", "namespace", pod.Namespace, "pod", pod.Name, "container", cs.Name)
+				continue
+			}
+			defaultZone, ok := containerZones[cs.Name]
+			if !ok {
+				defaultZone = planUnknownZone
+			}
+			for _, change := range parsePlannedChanges(logs, defaultZone) {
+				if !seen[change] {
+					seen[change] = true
+					changes = append(changes, change)
+				}
+			}
+		}
+	}
+	return changes, nil
+}
+
+// desiredExternalDNSPlanConfigMap returns the desired plan configmap resource.
+// The configmap lists the planned changes and their summary per zone.
+func desiredExternalDNSPlanConfigMap(nsName types.NamespacedName, changes []plannedChange) *corev1.ConfigMap {
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+	sort.Strings(lines)
+
+	summary := []string{}
+	for _, zone := range planZones(changes) {
+		create, update, del := countPlannedChanges(changes, zone)
+		summary = append(summary, fmt.Sprintf("zone=%s create=%d update=%d delete=%d", zone, create, update, del))
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nsName.Name,
+			Namespace: nsName.Namespace,
+			Labels:    controller.ExternalDNSOperandLabels(),
+		},
+		Data: map[string]string{
+			planChangesKey: strings.Join(lines, "\n"),
+			planSummaryKey: strings.Join(summary, "\n"),
+		},
+	}
+}
+
+// computeDryRunCondition returns an externalDNS condition which summarizes the given planned changes.
+func computeDryRunCondition(nsName types.NamespacedName, changes []plannedChange) metav1.Condition {
+	if len(changes) == 0 {
+		return metav1.Condition{
+			Type:    ExternalDNSDryRunConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "NoChangesPlanned",
+			Message: "Dry run mode is enabled, no changes to the DNS records are planned",
+		}
+	}
+	create, update, del := countPlannedChanges(changes, "")
+	return metav1.Condition{
+		Type:   ExternalDNSDryRunConditionType,
+		Status: metav1.ConditionTrue,
+		Reason: "ChangesPlanned",
+		Message: fmt.Sprintf("Dry run mode is enabled, planned changes in %d zone(s): %d create(s), %d update(s), %d delete(s), see configmap %s",
+			len(planZones(changes)), create, update, del, nsName),
+	}
+}
+
+// planZones returns the sorted list of the zones of the given changes.
+func planZones(changes []plannedChange) []string {
+	zoneSet := map[string]bool{}
+	for _, change := range changes {
+		zoneSet[change.zone] = true
+	}
+	zones := make([]string, 0, len(zoneSet))
+	for zone := range zoneSet {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// countPlannedChanges returns the number of creates, updates and deletes planned in the given zone,
+// all the zones are counted if the zone is empty.
+func countPlannedChanges(changes []plannedChange, zone string) (int, int, int) {
+	counts := map[string]int{}
+	for _, change := range changes {
+		if zone == "" || change.zone == zone {
+			counts[change.action]++
+		}
+	}
+	return counts[planActionCreate], counts[planActionUpdate], counts[planActionDelete]
+}
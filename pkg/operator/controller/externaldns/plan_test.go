@@ -0,0 +1,212 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+func TestParsePlannedChanges(t *testing.T) {
+	testCases := []struct {
+		name            string
+		logs            string
+		expectedChanges []plannedChange
+	}{
+		{
+			name:            "No changes",
+			logs:            `time="2024-01-01T00:00:00Z" level=info msg="All records are already up to date"`,
+			expectedChanges: []plannedChange{},
+		},
+		{
+			name: "AWS",
+			logs: `time="2024-01-01T00:00:00Z" level=info msg="Desired change: CREATE foo.example.com A [Id: /hostedzone/Z1]"
+time="2024-01-01T00:00:00Z" level=info msg="Desired change: UPSERT bar.example.com CNAME [Id: /hostedzone/Z1]"
+time="2024-01-01T00:00:00Z" level=info msg="Desired change: DELETE baz.example.com TXT [Id: /hostedzone/Z2]"
+time="2024-01-01T00:01:00Z" level=info msg="Desired change: CREATE foo.example.com A [Id: /hostedzone/Z1]"`,
+			expectedChanges: []plannedChange{
+				{action: planActionCreate, name: "foo.example.com", recordType: "A", zone: "/hostedzone/Z1"},
+				{action: planActionUpdate, name: "bar.example.com", recordType: "CNAME", zone: "/hostedzone/Z1"},
+				{action: planActionDelete, name: "baz.example.com", recordType: "TXT", zone: "/hostedzone/Z2"},
+			},
+		},
+		{
+			name: "Azure",
+			logs: `time="2024-01-01T00:00:00Z" level=info msg="Would create A record named 'foo' to '1.2.3.4' for Azure DNS zone 'example.com'."
+time="2024-01-01T00:00:00Z" level=info msg="Would delete TXT record named 'bar' for Azure DNS zone 'example.com'."`,
+			expectedChanges: []plannedChange{
+				{action: planActionCreate, name: "foo", recordType: "A", zone: "example.com"},
+				{action: planActionDelete, name: "bar", recordType: "TXT", zone: "example.com"},
+			},
+		},
+		{
+			name: "Cloudflare",
+			logs: `time="2024-01-01T00:00:00Z" level=info msg="Changing record." action=CREATE record=foo.example.com ttl=1 type=A zone=023e105f4ecef8ad9ca31a8372d0c353`,
+			expectedChanges: []plannedChange{
+				{action: planActionCreate, name: "foo.example.com", recordType: "A", zone: "023e105f4ecef8ad9ca31a8372d0c353"},
+			},
+		},
+		{
+			name: "Cloudflare in JSON format",
+			logs: `{"action":"CREATE","level":"info","msg":"Changing record.","record":"foo.example.com","time":"2024-01-01T00:00:00Z","ttl":1,"type":"A","zone":"023e105f4ecef8ad9ca31a8372d0c353"}`,
+			expectedChanges: []plannedChange{
+				{action: planActionCreate, name: "foo.example.com", recordType: "A", zone: "023e105f4ecef8ad9ca31a8372d0c353"},
+			},
+		},
+		{
+			name: "AWS in JSON format",
+			logs: `{"level":"info","msg":"Desired change: CREATE foo.example.com A [Id: /hostedzone/Z1]","time":"2024-01-01T00:00:00Z"}`,
+			expectedChanges: []plannedChange{
+				{action: planActionCreate, name: "foo.example.com", recordType: "A", zone: "/hostedzone/Z1"},
+			},
+		},
+		{
+			name: "GCP without zone",
+			logs: `time="2024-01-01T00:00:00Z" level=info msg="Add records: foo.example.com. A [1.2.3.4] 300"
+time="2024-01-01T00:00:00Z" level=info msg="Del records: bar.example.com. TXT [\"heritage=external-dns\"] 300"`,
+			expectedChanges: []plannedChange{
+				{action: planActionCreate, name: "foo.example.com.", recordType: "A", zone: "default-zone"},
+				{action: planActionDelete, name: "bar.example.com.", recordType: "TXT", zone: "default-zone"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			changes := parsePlannedChanges(tc.logs, "default-zone")
+			if diff := cmp.Diff(tc.expectedChanges, changes, cmp.AllowUnexported(plannedChange{})); diff != "" {
+				t.Errorf("unexpected planned changes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnsureExternalDNSDryRunPlan(t *testing.T) {
+	deployment := fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator")
+	deployment.Namespace = "external-dns-operator"
+	containerName := controller.ExternalDNSContainerName("public-zone")
+	pod := fakePodWithContainerStatus("pod", "external-dns-operator", corev1.ContainerStatus{
+		Name:  containerName,
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	})
+	logs := `time="2024-01-01T00:00:00Z" level=info msg="Add records: foo.example.com. A [1.2.3.4] 300"
+time="2024-01-01T00:00:00Z" level=info msg="Add records: bar.example.com. A [1.2.3.4] 300"
+time="2024-01-01T00:00:00Z" level=info msg="Del records: baz.example.com. A [1.2.3.4] 300"`
+
+	testCases := []struct {
+		name              string
+		dryRun            bool
+		existingObjects   []runtime.Object
+		podLogs           podLogsFunc
+		expectedCondition metav1.Condition
+		expectedData      map[string]string
+	}{
+		{
+			name:            "Dry run disabled removes plan",
+			existingObjects: []runtime.Object{&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: test.OperandName + "-plan", Namespace: test.OperandNamespace}}},
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSDryRunConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  "DryRunDisabled",
+				Message: "The changes to the DNS records are applied",
+			},
+		},
+		{
+			name:            "Dry run with changes",
+			dryRun:          true,
+			existingObjects: []runtime.Object{&pod},
+			podLogs:         fakePodLogs(logs, nil),
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSDryRunConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "ChangesPlanned",
+				Message: "Dry run mode is enabled, planned changes in 1 zone(s): 2 create(s), 0 update(s), 1 delete(s), see configmap " + test.OperandNamespace + "/" + test.OperandName + "-plan",
+			},
+			expectedData: map[string]string{
+				planChangesKey: "create bar.example.com. A zone=public-zone\ncreate foo.example.com. A zone=public-zone\ndelete baz.example.com. A zone=public-zone",
+				planSummaryKey: "zone=public-zone create=2 update=0 delete=1",
+			},
+		},
+		{
+			name:            "Dry run with unreadable logs",
+			dryRun:          true,
+			existingObjects: []runtime.Object{&pod},
+			podLogs:         fakePodLogs("", errors.NewForbidden(corev1.Resource("pods/log"), "pod", nil)),
+			expectedCondition: metav1.Condition{
+				Type:    ExternalDNSDryRunConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "NoChangesPlanned",
+				Message: "Dry run mode is enabled, no changes to the DNS records are planned",
+			},
+			expectedData: map[string]string{
+				planChangesKey: "",
+				planSummaryKey: "",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := fakeExternalDNS()
+			extDNS.Spec.DryRun = tc.dryRun
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(tc.existingObjects...).Build()
+			r := &reconciler{
+				client:  cl,
+				scheme:  test.Scheme,
+				config:  testConfig(),
+				log:     zap.New(zap.UseDevMode(true)),
+				podLogs: tc.podLogs,
+			}
+
+			cond, err := r.ensureExternalDNSDryRunPlan(context.TODO(), test.OperandNamespace, extDNS, &deployment)
+			if err != nil {
+				t.Fatalf("expected no error from calling ensureExternalDNSDryRunPlan, but received %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedCondition, cond); diff != "" {
+				t.Errorf("unexpected condition (-want +got):\n%s", diff)
+			}
+
+			cm := &corev1.ConfigMap{}
+			err = cl.Get(context.TODO(), controller.ExternalDNSPlanConfigMapName(test.OperandNamespace, extDNS), cm)
+			if tc.expectedData == nil {
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected plan configmap to be absent, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get plan configmap: %v", err)
+			}
+			if diff := cmp.Diff(tc.expectedData, cm.Data); diff != "" {
+				t.Errorf("unexpected plan configmap data (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
 	configv1 "github.com/openshift/api/config/v1"
@@ -41,7 +42,13 @@ const (
 	defaultConfigMountPath        = "/etc/kubernetes"
 	defaultTXTRecordPrefix        = "external-dns-"
 	defaultTXTWildcardReplacement = "any"
+	defaultCRDSourceKind          = "DNSEndpoint"
+	defaultCRDSourceAPIVersion    = "externaldns.k8s.io/v1alpha1"
+	emptySource                   = "empty"
 	providerArg                   = "--provider="
+	externalDNSRegistryTXT        = "txt"
+	externalDNSRegistryNoop       = "noop"
+	txtEncryptAESKeyEnvVar        = "EXTERNAL_DNS_TXT_ENCRYPT_AES_KEY"
 	httpProxyEnvVar               = "HTTP_PROXY"
 	httpsProxyEnvVar              = "HTTPS_PROXY"
 	noProxyEnvVar                 = "NO_PROXY"
@@ -71,21 +78,36 @@ const (
 	boundSATokenExpirationSeconds = 3600
 	boundSATokenPath              = "token"
 	boundSATokenMountPath         = "/var/run/secrets/openshift/serviceaccount"
+	awsRoleARNEnvVar              = "AWS_ROLE_ARN"
+	awsWebIdentityTokenFileEnvVar = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	awsWebIdentityTokenVolumeName = "aws-web-identity-token"
+	awsWebIdentityTokenMountPath  = "/var/run/secrets/aws/serviceaccount"
+	awsWebIdentityTokenFilePath   = awsWebIdentityTokenMountPath + "/" + boundSATokenPath
+	awsDefaultWebIdentityAudience = "sts.amazonaws.com"
 	//
 	// Azure
 	//
-	azureConfigVolumeName = "azure-config-file"
-	azureConfigMountPath  = defaultConfigMountPath
-	azureConfigFileName   = "azure.json"
-	azureConfigFileKey    = "azure.json"
+	azureConfigVolumeName                = "azure-config-file"
+	azureConfigMountPath                 = defaultConfigMountPath
+	azureConfigFileName                  = "azure.json"
+	azureConfigFileKey                   = "azure.json"
+	azureClientIDEnvVar                  = "AZURE_CLIENT_ID"
+	azureTenantIDEnvVar                  = "AZURE_TENANT_ID"
+	azureFederatedTokenFileEnvVar        = "AZURE_FEDERATED_TOKEN_FILE"
+	azureFederatedTokenVolumeName        = "azure-identity-token"
+	azureFederatedTokenPath              = "azure-identity-token"
+	azureFederatedTokenMountPath         = "/var/run/secrets/azure/tokens"
+	azureFederatedTokenFilePath          = azureFederatedTokenMountPath + "/" + azureFederatedTokenPath
+	azureDefaultWorkloadIdentityAudience = "api://AzureADTokenExchange"
 	//
 	// GCP
 	//
-	gcpCredentialsVolumeName = "gcp-credentials-file"
-	gcpCredentialsMountPath  = defaultConfigMountPath
-	gcpCredentialsFileKey    = "gcp-credentials.json"
-	gcpCredentialsFileName   = "gcp-credentials.json"
-	gcpAppCredentialsEnvVar  = "GOOGLE_APPLICATION_CREDENTIALS"
+	gcpCredentialsVolumeName           = "gcp-credentials-file"
+	gcpCredentialsMountPath            = defaultConfigMountPath
+	gcpCredentialsFileKey              = "gcp-credentials.json"
+	gcpCredentialsFileName             = "gcp-credentials.json"
+	gcpAppCredentialsEnvVar            = "GOOGLE_APPLICATION_CREDENTIALS"
+	gcpWorkloadIdentityTokenVolumeName = "gcp-workload-identity-token"
 	//
 	// BlueCat
 	//
@@ -100,19 +122,55 @@ const (
 	infobloxWAPIPasswordEnvVar = "EXTERNAL_DNS_INFOBLOX_WAPI_PASSWORD"
 	infobloxWAPIUsernameKey    = "EXTERNAL_DNS_INFOBLOX_WAPI_USERNAME"
 	infobloxWAPIPasswordKey    = "EXTERNAL_DNS_INFOBLOX_WAPI_PASSWORD"
+	//
+	// Cloudflare
+	//
+	cloudflareAPITokenEnvVar = "CF_API_TOKEN"
+	cloudflareAPITokenKey    = "CF_API_TOKEN"
+	//
+	// RFC2136
+	//
+	rfc2136TSIGSecretEnvVar       = "EXTERNAL_DNS_RFC2136_TSIG_SECRET"
+	rfc2136TSIGSecretKey          = "EXTERNAL_DNS_RFC2136_TSIG_SECRET"
+	rfc2136KerberosUsernameEnvVar = "EXTERNAL_DNS_RFC2136_KERBEROS_USERNAME"
+	rfc2136KerberosUsernameKey    = "EXTERNAL_DNS_RFC2136_KERBEROS_USERNAME"
+	rfc2136KerberosPasswordEnvVar = "EXTERNAL_DNS_RFC2136_KERBEROS_PASSWORD"
+	rfc2136KerberosPasswordKey    = "EXTERNAL_DNS_RFC2136_KERBEROS_PASSWORD"
+	//
+	// Webhook
+	//
+	webhookProviderContainerName = "webhook-provider"
+	webhookProviderHost          = "localhost"
+	defaultWebhookProviderPort   = 8888
+	webhookProviderPortName      = "webhook"
+	// the provider gets up to 1 minute to start listening
+	webhookProviderStartupFailureThreshold = 30
+	webhookProviderStartupPeriodSeconds    = 2
+	//
+	// Metrics
+	//
+	metricsProxyContainerPrefix = "kube-rbac-proxy-"
+	metricsProxyStartPort       = 8443
+	metricsPortNamePrefix       = "metrics-"
+	metricsCertVolumeName       = "metrics-cert"
+	metricsCertMountPath        = "/etc/tls/private"
 )
 
 // externalDNSContainerBuilder builds the definition of the containers for ExternalDNS POD
 type externalDNSContainerBuilder struct {
 	image          string
 	provider       string
-	source         string
+	sources        []string
 	volumes        []corev1.Volume
 	secretName     string
 	externalDNS    *operatorv1beta1.ExternalDNS
 	isOpenShift    bool
 	platformStatus *configv1.PlatformStatus
-	counter        int
+	// cleanup makes the containers run a single synchronization
+	// which removes all the DNS records owned by ExternalDNS instance
+	cleanup           bool
+	metricsProxyImage string
+	counter           int
 }
 
 // build returns the definition of a single container for the given DNS zone with unique metrics port
@@ -126,6 +184,7 @@ func (b *externalDNSContainerBuilder) build(zone string) (*corev1.Container, err
 // sequence param is used to create the unique metrics port
 func (b *externalDNSContainerBuilder) buildSeq(seq int, zone string) (*corev1.Container, error) {
 	container := b.defaultContainer(controller.ExternalDNSContainerName(zone))
+	container.Resources = containerResources(b.externalDNS.Spec.Deployment.Resources.ExternalDNS)
 	err := b.fillProviderAgnosticFields(seq, zone, container)
 	if err != nil {
 		return nil, err
@@ -134,6 +193,58 @@ func (b *externalDNSContainerBuilder) buildSeq(seq int, zone string) (*corev1.Co
 	return container, nil
 }
 
+// buildMetricsProxy returns the definition of kube-rbac-proxy container
+// which exposes the metrics of ExternalDNS container with the given sequence number.
+// The serving certificate is generated by the service CA on OpenShift,
+// kube-rbac-proxy uses a self-signed certificate otherwise.
+func (b *externalDNSContainerBuilder) buildMetricsProxy(seq int) *corev1.Container {
+	container := b.defaultContainer(metricsProxyContainerName(seq))
+	container.Image = b.metricsProxyImage
+	container.Resources = containerResources(b.externalDNS.Spec.Deployment.Resources.MetricsProxy)
+	container.Args = []string{
+		fmt.Sprintf("--secure-listen-address=0.0.0.0:%d", metricsProxyStartPort+seq),
+		fmt.Sprintf("--upstream=http://%s:%d/", defaultMetricsAddress, defaultMetricsStartPort+seq),
+		"--logtostderr=true",
+		"--http2-disable",
+	}
+	container.Ports = []corev1.ContainerPort{
+		{
+			Name:          metricsPortName(seq),
+			ContainerPort: int32(metricsProxyStartPort + seq),
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
+	if b.isOpenShift {
+		container.Args = append(container.Args,
+			fmt.Sprintf("--tls-cert-file=%s/%s", metricsCertMountPath, corev1.TLSCertKey),
+			fmt.Sprintf("--tls-private-key-file=%s/%s", metricsCertMountPath, corev1.TLSPrivateKeyKey),
+		)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      metricsCertVolumeName,
+			MountPath: metricsCertMountPath,
+			ReadOnly:  true,
+		})
+	}
+	return container
+}
+
+// metricsProxyContainerName returns the name of kube-rbac-proxy container
+// which exposes the metrics of ExternalDNS container with the given sequence number.
+func metricsProxyContainerName(seq int) string {
+	return fmt.Sprintf("%s%d", metricsProxyContainerPrefix, seq)
+}
+
+// isMetricsProxyContainer returns true if the given container is kube-rbac-proxy which exposes the metrics.
+func isMetricsProxyContainer(name string) bool {
+	return strings.HasPrefix(name, metricsProxyContainerPrefix)
+}
+
+// metricsPortName returns the name of the port which exposes
+// the metrics of ExternalDNS container with the given sequence number.
+func metricsPortName(seq int) string {
+	return fmt.Sprintf("%s%d", metricsPortNamePrefix, seq)
+}
+
 // defaultContainer returns the initial definition of any container of ExternalDNS POD
 func (b *externalDNSContainerBuilder) defaultContainer(name string) *corev1.Container {
 	return &corev1.Container{
@@ -156,6 +267,15 @@ func (b *externalDNSContainerBuilder) defaultContainer(name string) *corev1.Cont
 	}
 }
 
+// containerResources returns the copy of the given compute resources,
+// no resources are requested if nil.
+func containerResources(resources *corev1.ResourceRequirements) corev1.ResourceRequirements {
+	if resources == nil {
+		return corev1.ResourceRequirements{}
+	}
+	return *resources.DeepCopy()
+}
+
 // fillProviderAgnosticFields fills the given container with the data agnostic to any provider
 func (b *externalDNSContainerBuilder) fillProviderAgnosticFields(seq int, zone string, container *corev1.Container) error {
 	//
@@ -163,37 +283,144 @@ func (b *externalDNSContainerBuilder) fillProviderAgnosticFields(seq int, zone s
 	//
 	args := []string{
 		fmt.Sprintf("--metrics-address=%s:%d", defaultMetricsAddress, defaultMetricsStartPort+seq),
-		fmt.Sprintf("--txt-owner-id=%s-%s", defaultOwnerPrefix, b.externalDNS.Name),
+	}
+
+	if b.hasTXTRegistry() {
+		args = append(args, fmt.Sprintf("--txt-owner-id=%s", b.txtOwnerID()))
+		if from := txtMigrateFromOwnerID(b.externalDNS); from != "" {
+			args = append(args, migrateFromTXTOwnerArg(from))
+		}
+	}
+
+	args = append(args,
 		fmt.Sprintf("--provider=%s", b.provider),
-		fmt.Sprintf("--source=%s", b.source),
-		"--policy=sync",
-		"--registry=txt",
-		"--log-level=debug",
+		fmt.Sprintf("--policy=%s", b.policy()),
+		fmt.Sprintf("--registry=%s", b.registry()),
+		fmt.Sprintf("--log-level=%s", b.logLevel()),
+	)
+
+	// the text format is the default of ExternalDNS,
+	// the flag is omitted to not roll out the existing deployments
+	if b.externalDNS.Spec.Logging.Format == operatorv1beta1.LogFormatJSON {
+		args = append(args, "--log-format=json")
 	}
 
 	if zone != "" {
 		args = append(args, fmt.Sprintf("--zone-id-filter=%s", zone))
 	}
 
-	if b.externalDNS.Spec.Source.LabelFilter != nil {
-		args = append(args, fmt.Sprintf("--label-filter=%s", metav1.FormatLabelSelector(b.externalDNS.Spec.Source.LabelFilter)))
+	// the cleanup is skipped for the dry run instances,
+	// the job must not delete the records if it's ever run for one
+	if b.externalDNS.Spec.DryRun {
+		args = append(args, "--dry-run")
 	}
 
-	if b.externalDNS.Spec.Source.Service != nil && len(b.externalDNS.Spec.Source.Service.ServiceType) > 0 {
-		publishInternal := false
-		for _, serviceType := range b.externalDNS.Spec.Source.Service.ServiceType {
-			args = append(args, fmt.Sprintf("--service-type-filter=%s", string(serviceType)))
-			if serviceType == corev1.ServiceTypeClusterIP {
-				publishInternal = true
-			}
-		}
+	if b.cleanup {
+		// the empty source doesn't produce any endpoint,
+		// the sync policy deletes all the records owned by the instance
+		args = append(args, fmt.Sprintf("--source=%s", emptySource), "--once")
+	} else {
+		args = append(args, b.sourceFields()...)
+	}
 
-		// legacy option before the service-type-filter was introduced
-		// must be there though, ClusterIP endpoints won't be added without it
-		if publishInternal {
-			args = append(args, "--publish-internal-services")
+	filterArgs, err := b.domainFilters()
+	if err != nil {
+		return err
+	}
+
+	container.Args = append(container.Args, filterArgs...)
+	container.Args = append(container.Args, args...)
+
+	b.fillTXTEncryptionFields(container)
+	b.fillProxyAndTrustedCAFields(container)
+
+	return nil
+}
+
+// registry returns the registry argument for ExternalDNS
+func (b *externalDNSContainerBuilder) registry() string {
+	if b.externalDNS.Spec.Registry.Type == operatorv1beta1.RegistryTypeNoop {
+		return externalDNSRegistryNoop
+	}
+	return externalDNSRegistryTXT
+}
+
+// hasTXTRegistry returns true if ExternalDNS keeps the ownership of the records in TXT records
+func (b *externalDNSContainerBuilder) hasTXTRegistry() bool {
+	return b.registry() == externalDNSRegistryTXT
+}
+
+// txtOwnerID returns the owner ID stored in the TXT records,
+// the one derived from the name of ExternalDNS instance is used if none is given
+func (b *externalDNSContainerBuilder) txtOwnerID() string {
+	if txt := b.externalDNS.Spec.Registry.TXT; txt != nil && txt.OwnerID != "" {
+		return txt.OwnerID
+	}
+	return fmt.Sprintf("%s-%s", defaultOwnerPrefix, b.externalDNS.Name)
+}
+
+// fillTXTEncryptionFields fills the given container with the AES key
+// which encrypts the values of the TXT records
+func (b *externalDNSContainerBuilder) fillTXTEncryptionFields(container *corev1.Container) {
+	txt := b.externalDNS.Spec.Registry.TXT
+	// the key is taken from the credentials secret,
+	// the webhook rejects the encryption without the secret referenced by the provider
+	if !b.hasTXTRegistry() || txt == nil || txt.Encryption == nil || len(b.secretName) == 0 {
+		return
+	}
+
+	container.Args = append(container.Args, "--txt-encrypt-enabled")
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name: txtEncryptAESKeyEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: b.secretName,
+				},
+				Key: txt.Encryption.AESKeySecretKey,
+			},
+		},
+	})
+}
+
+// policy returns the policy argument for ExternalDNS,
+// the records cleanup always needs the sync policy to delete the records
+func (b *externalDNSContainerBuilder) policy() string {
+	if b.cleanup {
+		return externalDNSPolicySync
+	}
+	if policy, ok := policyStringTable[b.externalDNS.Spec.Policy]; ok {
+		return policy
+	}
+	return externalDNSPolicySync
+}
+
+// logLevel returns the log level argument for ExternalDNS.
+// The dry run plan and the owner migration are followed in the info messages,
+// the level is raised to info while any of them is active.
+func (b *externalDNSContainerBuilder) logLevel() string {
+	level := b.externalDNS.Spec.Logging.Level
+	if level == operatorv1beta1.LogLevelWarn || level == operatorv1beta1.LogLevelError {
+		if !b.cleanup && (b.externalDNS.Spec.DryRun || txtMigrateFromOwnerID(b.externalDNS) != "") {
+			level = operatorv1beta1.LogLevelInfo
 		}
 	}
+	if arg, ok := logLevelStringTable[level]; ok {
+		return arg
+	}
+	return externalDNSLogLevelDebug
+}
+
+// sourceFields returns the args of the sources and the options shared among them
+func (b *externalDNSContainerBuilder) sourceFields() []string {
+	var args []string
+
+	// the source specific options are rendered into the flags
+	// shared by all the sources, the webhook ensures they don't conflict
+	for i, source := range externalDNSSources(b.externalDNS) {
+		args = append(args, fmt.Sprintf("--source=%s", b.sources[i]))
+		args = append(args, sourceArgs(source)...)
+	}
 
 	if b.externalDNS.Spec.Source.HostnameAnnotationPolicy == operatorv1beta1.HostnameAnnotationPolicyIgnore {
 		args = append(args, "--ignore-hostname-annotation")
@@ -202,31 +429,23 @@ func (b *externalDNSContainerBuilder) fillProviderAgnosticFields(seq int, zone s
 	if len(b.externalDNS.Spec.Source.FQDNTemplate) > 0 {
 		args = append(args, fmt.Sprintf("--fqdn-template=%s", strings.Join(b.externalDNS.Spec.Source.FQDNTemplate, ",")))
 	} else {
-		// ExternalDNS needs FQDNTemplate if the hostname annotation is ignored even for Route source.
-		// However it doesn't make much sense as the hostname is retrieved from the route's spec.
+		// ExternalDNS needs FQDNTemplate if the hostname annotation is ignored even for Route, Ingress, CRD and Gateway API route sources.
+		// However it doesn't make much sense as the hostname is retrieved from the route's (or ingress's, DNSEndpoint's) spec.
 		// Feeding ExternalDNS with some dummy template just to pass the validation.
-		if b.externalDNS.Spec.Source.HostnameAnnotationPolicy == operatorv1beta1.HostnameAnnotationPolicyIgnore &&
-			b.externalDNS.Spec.Source.Type == operatorv1beta1.SourceTypeRoute {
+		if b.externalDNS.Spec.Source.HostnameAnnotationPolicy == operatorv1beta1.HostnameAnnotationPolicyIgnore && !hasServiceSource(b.externalDNS) {
 			args = append(args, "--fqdn-template={{\"\"}}")
 		}
 	}
 
-	if b.externalDNS.Spec.Source.OpenShiftRoute != nil && len(b.externalDNS.Spec.Source.OpenShiftRoute.RouterName) > 0 {
-		args = append(args, fmt.Sprintf("--openshift-router-name=%s", b.externalDNS.Spec.Source.OpenShiftRoute.RouterName))
-	}
-
 	if b.externalDNS.Spec.IntervalSeconds > 0 {
 		args = append(args, fmt.Sprintf("--interval=%ds", b.externalDNS.Spec.IntervalSeconds))
 	}
 
-	filterArgs, err := b.domainFilters()
-	if err != nil {
-		return err
-	}
-
-	container.Args = append(container.Args, filterArgs...)
-	container.Args = append(container.Args, args...)
+	return args
+}
 
+// fillProxyAndTrustedCAFields fills the given container with the cluster wide proxy settings and the trusted CA bundle
+func (b *externalDNSContainerBuilder) fillProxyAndTrustedCAFields(container *corev1.Container) {
 	//
 	// ENV
 	//
@@ -256,8 +475,109 @@ func (b *externalDNSContainerBuilder) fillProviderAgnosticFields(seq int, zone s
 			container.Env = append(container.Env, corev1.EnvVar{Name: sslCertDirEnvVar, Value: trustedCAExtractedPEMDir})
 		}
 	}
+}
 
-	return nil
+// sourceArgs returns the args specific to the given source.
+func sourceArgs(source operatorv1beta1.ExternalDNSSourceUnion) []string {
+	var args []string
+
+	if source.LabelFilter != nil {
+		args = append(args, fmt.Sprintf("--label-filter=%s", metav1.FormatLabelSelector(source.LabelFilter)))
+	}
+
+	if source.Type == operatorv1beta1.SourceTypeCRD {
+		args = append(args, crdSourceArgs(source.CRD)...)
+	}
+
+	if gatewayRouteSourceTypes[source.Type] {
+		args = append(args, gatewayRouteSourceArgs(source.Gateway)...)
+	}
+
+	if source.Service != nil && len(source.Service.ServiceType) > 0 {
+		publishInternal := false
+		for _, serviceType := range source.Service.ServiceType {
+			args = append(args, fmt.Sprintf("--service-type-filter=%s", string(serviceType)))
+			if serviceType == corev1.ServiceTypeClusterIP {
+				publishInternal = true
+			}
+		}
+
+		// legacy option before the service-type-filter was introduced
+		// must be there though, ClusterIP endpoints won't be added without it
+		if publishInternal {
+			args = append(args, "--publish-internal-services")
+		}
+	}
+
+	if source.Ingress != nil {
+		for _, className := range source.Ingress.IngressClassNames {
+			args = append(args, fmt.Sprintf("--ingress-class=%s", className))
+		}
+		if source.Ingress.IgnoreTLSSpec {
+			args = append(args, "--ignore-ingress-tls-spec")
+		}
+		if source.Ingress.IgnoreRulesSpec {
+			args = append(args, "--ignore-ingress-rules-spec")
+		}
+	}
+
+	if source.OpenShiftRoute != nil && len(source.OpenShiftRoute.RouterName) > 0 {
+		args = append(args, fmt.Sprintf("--openshift-router-name=%s", source.OpenShiftRoute.RouterName))
+	}
+
+	return args
+}
+
+// hasServiceSource returns true if the service source is one of the sources of the given ExternalDNS.
+func hasServiceSource(extDNS *operatorv1beta1.ExternalDNS) bool {
+	for _, source := range externalDNSSources(extDNS) {
+		if source.Type == operatorv1beta1.SourceTypeService {
+			return true
+		}
+	}
+	return false
+}
+
+// crdSourceArgs returns the args specific to the CRD source,
+// DNSEndpoint resources are consumed if no CRD source options are given.
+func crdSourceArgs(crd *operatorv1beta1.ExternalDNSCRDSourceOptions) []string {
+	kind, apiVersion := defaultCRDSourceKind, defaultCRDSourceAPIVersion
+	if crd != nil {
+		kind, apiVersion = crd.Kind, crd.Version
+	}
+
+	args := []string{
+		fmt.Sprintf("--crd-source-apiversion=%s", apiVersion),
+		fmt.Sprintf("--crd-source-kind=%s", kind),
+	}
+
+	if crd != nil && crd.LabelFilter != nil {
+		args = append(args, fmt.Sprintf("--label-filter=%s", metav1.FormatLabelSelector(crd.LabelFilter)))
+	}
+
+	return args
+}
+
+// gatewayRouteSourceArgs returns the args specific to the Gateway API route sources.
+func gatewayRouteSourceArgs(gateway *operatorv1beta1.ExternalDNSGatewayRouteSourceOptions) []string {
+	var args []string
+	if gateway == nil {
+		return args
+	}
+
+	if len(gateway.GatewayNamespace) > 0 {
+		args = append(args, fmt.Sprintf("--gateway-namespace=%s", gateway.GatewayNamespace))
+	}
+
+	if gateway.GatewayLabelFilter != nil {
+		args = append(args, fmt.Sprintf("--gateway-label-filter=%s", metav1.FormatLabelSelector(gateway.GatewayLabelFilter)))
+	}
+
+	if gateway.RouteLabelFilter != nil {
+		args = append(args, fmt.Sprintf("--label-filter=%s", metav1.FormatLabelSelector(gateway.RouteLabelFilter)))
+	}
+
+	return args
 }
 
 func (b *externalDNSContainerBuilder) domainFilters() ([]string, error) {
@@ -337,12 +657,18 @@ func (b *externalDNSContainerBuilder) fillProviderSpecificFields(zone string, co
 		b.fillBlueCatFields(container)
 	case externalDNSProviderTypeInfoblox:
 		b.fillInfobloxFields(container)
+	case externalDNSProviderTypeCloudflare:
+		b.fillCloudflareFields(container)
+	case externalDNSProviderTypeRFC2136:
+		b.fillRFC2136Fields(container)
+	case externalDNSProviderTypeWebhook:
+		b.fillWebhookFields(container)
 	}
 }
 
 // fillAWSFields fills the given container with the data specific to AWS provider
 func (b *externalDNSContainerBuilder) fillAWSFields(container *corev1.Container) {
-	container.Args = addTXTPrefixFlag(container.Args)
+	container.Args = b.addTXTRegistryFlags(container.Args)
 
 	if b.platformStatus != nil && b.platformStatus.AWS != nil && utils.IsUSGovAWSRegion(b.platformStatus.AWS.Region) {
 		// See https://github.com/kubernetes-sigs/external-dns/blob/master/docs/tutorials/aws.md#govcloud-caveats
@@ -354,6 +680,20 @@ func (b *externalDNSContainerBuilder) fillAWSFields(container *corev1.Container)
 		container.Args = append(container.Args, fmt.Sprintf("--aws-assume-role=%s", b.externalDNS.Spec.Provider.AWS.AssumeRole.ARN))
 	}
 
+	// the web identity replaces the access keys of the credentials secret
+	if aws := b.externalDNS.Spec.Provider.AWS; aws != nil && aws.CredentialsMode == operatorv1beta1.AWSCredentialsModeWebIdentity {
+		if aws.WebIdentity != nil {
+			container.Env = append(container.Env, corev1.EnvVar{Name: awsRoleARNEnvVar, Value: aws.WebIdentity.RoleARN})
+		}
+		container.Env = append(container.Env, corev1.EnvVar{Name: awsWebIdentityTokenFileEnvVar, Value: awsWebIdentityTokenFilePath})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      awsWebIdentityTokenVolumeName,
+			MountPath: awsWebIdentityTokenMountPath,
+			ReadOnly:  true,
+		})
+		return
+	}
+
 	// don't add empty credentials environment variables if no secret was given
 	if len(b.secretName) == 0 {
 		return
@@ -381,10 +721,12 @@ func (b *externalDNSContainerBuilder) fillAWSFields(container *corev1.Container)
 // fillAzureFields fills the given container with the data specific to Azure provider
 func (b *externalDNSContainerBuilder) fillAzureFields(zone string, container *corev1.Container) {
 	// https://github.com/kubernetes-sigs/external-dns/issues/2082
-	container.Args = addTXTPrefixFlag(container.Args)
+	container.Args = b.addTXTRegistryFlags(container.Args)
 
 	// https://github.com/kubernetes-sigs/external-dns/issues/2922
-	container.Args = append(container.Args, fmt.Sprintf("--txt-wildcard-replacement=%s", defaultTXTWildcardReplacement))
+	if b.hasTXTRegistry() && (b.externalDNS.Spec.Registry.TXT == nil || b.externalDNS.Spec.Registry.TXT.WildcardReplacement == "") {
+		container.Args = append(container.Args, fmt.Sprintf("--txt-wildcard-replacement=%s", defaultTXTWildcardReplacement))
+	}
 
 	// check the zone field for the keyword 'privatednszones', this ensures that the
 	// provider 'azure-private-dns' is passed to the container
@@ -408,13 +750,29 @@ func (b *externalDNSContainerBuilder) fillAzureFields(zone string, container *co
 				ReadOnly:  true,
 			})
 		}
+		// federated token volume: the config file rendered by the operator
+		// enables the workload identity which reads the identity from the environment
+		if v.Name == azureFederatedTokenVolumeName {
+			if wi := b.externalDNS.Spec.Provider.Azure.WorkloadIdentity; wi != nil {
+				container.Env = append(container.Env,
+					corev1.EnvVar{Name: azureClientIDEnvVar, Value: wi.ClientID},
+					corev1.EnvVar{Name: azureTenantIDEnvVar, Value: wi.TenantID},
+				)
+			}
+			container.Env = append(container.Env, corev1.EnvVar{Name: azureFederatedTokenFileEnvVar, Value: azureFederatedTokenFilePath})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      v.Name,
+				MountPath: azureFederatedTokenMountPath,
+				ReadOnly:  true,
+			})
+		}
 	}
 }
 
 // fillGCPFields fills the given container with the data specific to Google provider
 func (b *externalDNSContainerBuilder) fillGCPFields(container *corev1.Container) {
 	// https://github.com/kubernetes-sigs/external-dns/issues/262
-	container.Args = addTXTPrefixFlag(container.Args)
+	container.Args = b.addTXTRegistryFlags(container.Args)
 
 	if !b.isOpenShift {
 		// don't add empty args if GCP provider is not given
@@ -441,6 +799,14 @@ func (b *externalDNSContainerBuilder) fillGCPFields(container *corev1.Container)
 				ReadOnly:  true,
 			})
 		}
+		// token volume: the credential config rendered by the operator points at it
+		if v.Name == gcpWorkloadIdentityTokenVolumeName {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      v.Name,
+				MountPath: controller.GCPWorkloadIdentityTokenMountPath,
+				ReadOnly:  true,
+			})
+		}
 	}
 }
 
@@ -448,7 +814,7 @@ func (b *externalDNSContainerBuilder) fillGCPFields(container *corev1.Container)
 func (b *externalDNSContainerBuilder) fillBlueCatFields(container *corev1.Container) {
 	// only standard CNAME records are supported
 	// https://docs.bluecatnetworks.com/r/Address-Manager-API-Guide/ENUM-number-generic-methods/9.2.0
-	container.Args = addTXTPrefixFlag(container.Args)
+	container.Args = b.addTXTRegistryFlags(container.Args)
 
 	// no volume mounts will be added if there is no config volume added before
 	for _, v := range b.volumes {
@@ -487,7 +853,7 @@ func (b *externalDNSContainerBuilder) fillInfobloxFields(container *corev1.Conta
 		args = append(args, fmt.Sprintf("--infoblox-max-results=%d", b.externalDNS.Spec.Provider.Infoblox.MaxResults))
 	}
 
-	args = addTXTPrefixFlag(args)
+	args = b.addTXTRegistryFlags(args)
 
 	env := []corev1.EnvVar{
 		{
@@ -518,19 +884,188 @@ func (b *externalDNSContainerBuilder) fillInfobloxFields(container *corev1.Conta
 	container.Env = append(container.Env, env...)
 }
 
+// fillCloudflareFields fills the given container with the data specific to Cloudflare provider
+func (b *externalDNSContainerBuilder) fillCloudflareFields(container *corev1.Container) {
+	// Cloudflare doesn't allow TXT and CNAME records with the same name
+	container.Args = b.addTXTRegistryFlags(container.Args)
+
+	if b.externalDNS.Spec.Provider.Cloudflare != nil && b.externalDNS.Spec.Provider.Cloudflare.Proxied {
+		container.Args = append(container.Args, "--cloudflare-proxied")
+	}
+
+	// don't add empty env vars if secret is not given
+	if len(b.secretName) == 0 {
+		return
+	}
+
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name: cloudflareAPITokenEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: b.secretName,
+				},
+				Key: cloudflareAPITokenKey,
+			},
+		},
+	})
+}
+
+// fillRFC2136Fields fills the given container with the data specific to RFC2136 provider
+func (b *externalDNSContainerBuilder) fillRFC2136Fields(container *corev1.Container) {
+	// don't add empty args or env vars if secret or rfc2136 provider is not given
+	if len(b.secretName) == 0 || b.externalDNS.Spec.Provider.RFC2136 == nil {
+		return
+	}
+	rfc2136 := b.externalDNS.Spec.Provider.RFC2136
+
+	args := []string{
+		fmt.Sprintf("--rfc2136-host=%s", rfc2136.Host),
+		fmt.Sprintf("--rfc2136-zone=%s", rfc2136.Zone),
+	}
+
+	if rfc2136.Port != 0 {
+		args = append(args, fmt.Sprintf("--rfc2136-port=%d", rfc2136.Port))
+	}
+
+	if len(rfc2136.TSIGKeyName) > 0 {
+		args = append(args, fmt.Sprintf("--rfc2136-tsig-keyname=%s", rfc2136.TSIGKeyName))
+	}
+
+	if len(rfc2136.TSIGSecretAlgorithm) > 0 {
+		args = append(args, fmt.Sprintf("--rfc2136-tsig-secret-alg=%s", rfc2136.TSIGSecretAlgorithm))
+	}
+
+	if rfc2136.TSIGAXFR {
+		args = append(args, "--rfc2136-tsig-axfr")
+	}
+
+	args = b.addTXTRegistryFlags(args)
+
+	secretEnvVar := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: b.secretName,
+					},
+					Key: key,
+				},
+			},
+		}
+	}
+
+	var env []corev1.EnvVar
+	if rfc2136.GSSTSIG != nil {
+		args = append(args, "--rfc2136-gss-tsig", fmt.Sprintf("--rfc2136-kerberos-realm=%s", rfc2136.GSSTSIG.KerberosRealm))
+		env = append(env,
+			secretEnvVar(rfc2136KerberosUsernameEnvVar, rfc2136KerberosUsernameKey),
+			secretEnvVar(rfc2136KerberosPasswordEnvVar, rfc2136KerberosPasswordKey),
+		)
+	} else {
+		env = append(env, secretEnvVar(rfc2136TSIGSecretEnvVar, rfc2136TSIGSecretKey))
+	}
+
+	container.Args = append(container.Args, args...)
+	container.Env = append(container.Env, env...)
+}
+
+// fillWebhookFields fills the given container with the data specific to Webhook provider
+func (b *externalDNSContainerBuilder) fillWebhookFields(container *corev1.Container) {
+	container.Args = append(container.Args, fmt.Sprintf("--webhook-provider-url=http://%s:%d", webhookProviderHost, webhookProviderPort(b.externalDNS.Spec.Provider.Webhook)))
+	container.Args = b.addTXTRegistryFlags(container.Args)
+}
+
+// buildWebhookProvider returns the definition of the webhook provider sidecar container
+func (b *externalDNSContainerBuilder) buildWebhookProvider() *corev1.Container {
+	webhook := b.externalDNS.Spec.Provider.Webhook
+	if webhook == nil {
+		return nil
+	}
+
+	container := b.defaultContainer(webhookProviderContainerName)
+	container.Image = webhook.Image
+	container.Resources = containerResources(b.externalDNS.Spec.Deployment.Resources.WebhookProvider)
+	container.Args = append(container.Args, webhook.Args...)
+	container.Ports = []corev1.ContainerPort{
+		{
+			Name:          webhookProviderPortName,
+			ContainerPort: int32(webhookProviderPort(webhook)),
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
+	// the startup probe holds the start of the cleanup containers
+	// until the provider listens, the native sidecars are started first
+	container.StartupProbe = webhookProviderProbe(webhookProviderStartupPeriodSeconds, webhookProviderStartupFailureThreshold)
+	container.ReadinessProbe = webhookProviderProbe(10, 3)
+
+	for _, env := range webhook.Env {
+		if len(env.SecretKey) == 0 {
+			container.Env = append(container.Env, corev1.EnvVar{Name: env.Name, Value: env.Value})
+			continue
+		}
+		// don't add env vars from secret if the secret is not given
+		if len(b.secretName) == 0 {
+			continue
+		}
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: env.Name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: b.secretName,
+					},
+					Key: env.SecretKey,
+				},
+			},
+		})
+	}
+
+	b.fillProxyAndTrustedCAFields(container)
+
+	return container
+}
+
+// webhookProviderProbe returns the probe which checks that the webhook provider listens on its port.
+// All the fields are set to prevent the fight with the defaulting.
+func webhookProviderProbe(periodSeconds, failureThreshold int32) *corev1.Probe {
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.FromString(webhookProviderPortName),
+			},
+		},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    periodSeconds,
+		SuccessThreshold: 1,
+		FailureThreshold: failureThreshold,
+	}
+}
+
+// webhookProviderPort returns the port of the webhook provider, the default one is used if the port is not given
+func webhookProviderPort(webhook *operatorv1beta1.ExternalDNSWebhookProviderOptions) int {
+	if webhook == nil || webhook.Port == 0 {
+		return defaultWebhookProviderPort
+	}
+	return webhook.Port
+}
+
 // externalDNSVolumeBuilder builds the definition of the volumes for ExternalDNS POD
 type externalDNSVolumeBuilder struct {
 	provider               string
 	secretName             string
 	trustedCAConfigMapName string
+	externalDNS            *operatorv1beta1.ExternalDNS
 }
 
 // newExternalDNSVolumeBuilder returns an instance of volume builder
-func newExternalDNSVolumeBuilder(provider, secretName, trustedCAConfigMapName string) *externalDNSVolumeBuilder {
+func newExternalDNSVolumeBuilder(provider, secretName, trustedCAConfigMapName string, externalDNS *operatorv1beta1.ExternalDNS) *externalDNSVolumeBuilder {
 	return &externalDNSVolumeBuilder{
 		provider:               provider,
 		secretName:             secretName,
 		trustedCAConfigMapName: trustedCAConfigMapName,
+		externalDNS:            externalDNS,
 	}
 }
 
@@ -582,6 +1117,29 @@ func (b *externalDNSVolumeBuilder) providerSpecificVolumes() []corev1.Volume {
 
 // awsVolumes returns volumes needed for AWS provider
 func (b *externalDNSVolumeBuilder) awsVolumes() []corev1.Volume {
+	if aws := b.externalDNS.Spec.Provider.AWS; aws != nil && aws.CredentialsMode == operatorv1beta1.AWSCredentialsModeWebIdentity {
+		audience := awsDefaultWebIdentityAudience
+		if aws.WebIdentity != nil && aws.WebIdentity.Audience != "" {
+			audience = aws.WebIdentity.Audience
+		}
+		return []corev1.Volume{
+			{
+				Name: awsWebIdentityTokenVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					Projected: &corev1.ProjectedVolumeSource{
+						Sources: []corev1.VolumeProjection{{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          audience,
+								ExpirationSeconds: ptr.To[int64](boundSATokenExpirationSeconds),
+								Path:              boundSATokenPath,
+							},
+						}},
+					},
+				},
+			},
+		}
+	}
+
 	if len(b.secretName) == 0 {
 		return nil
 	}
@@ -623,7 +1181,7 @@ func (b *externalDNSVolumeBuilder) azureVolumes() []corev1.Volume {
 		return nil
 	}
 
-	return []corev1.Volume{
+	volumes := []corev1.Volume{
 		{
 			Name: azureConfigVolumeName,
 			VolumeSource: corev1.VolumeSource{
@@ -639,6 +1197,29 @@ func (b *externalDNSVolumeBuilder) azureVolumes() []corev1.Volume {
 			},
 		},
 	}
+
+	if azure := b.externalDNS.Spec.Provider.Azure; azure != nil && azure.CredentialsMode == operatorv1beta1.AzureCredentialsModeWorkloadIdentity {
+		audience := azureDefaultWorkloadIdentityAudience
+		if azure.WorkloadIdentity != nil && azure.WorkloadIdentity.Audience != "" {
+			audience = azure.WorkloadIdentity.Audience
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: azureFederatedTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          audience,
+							ExpirationSeconds: ptr.To[int64](boundSATokenExpirationSeconds),
+							Path:              azureFederatedTokenPath,
+						},
+					}},
+				},
+			},
+		})
+	}
+
+	return volumes
 }
 
 // gcpVolumes returns volumes needed for Google provider
@@ -647,7 +1228,7 @@ func (b *externalDNSVolumeBuilder) gcpVolumes() []corev1.Volume {
 		return nil
 	}
 
-	return []corev1.Volume{
+	volumes := []corev1.Volume{
 		{
 			Name: gcpCredentialsVolumeName,
 			VolumeSource: corev1.VolumeSource{
@@ -663,6 +1244,30 @@ func (b *externalDNSVolumeBuilder) gcpVolumes() []corev1.Volume {
 			},
 		},
 	}
+
+	if gcp := b.externalDNS.Spec.Provider.GCP; gcp != nil && gcp.CredentialsMode == operatorv1beta1.GCPCredentialsModeWorkloadIdentityFederation && gcp.WorkloadIdentityFederation != nil {
+		// the default allowed audience of the workload identity pool provider
+		audience := "https:" + gcp.WorkloadIdentityFederation.Audience
+		if gcp.WorkloadIdentityFederation.TokenAudience != "" {
+			audience = gcp.WorkloadIdentityFederation.TokenAudience
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: gcpWorkloadIdentityTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          audience,
+							ExpirationSeconds: ptr.To[int64](boundSATokenExpirationSeconds),
+							Path:              controller.GCPWorkloadIdentityTokenPath,
+						},
+					}},
+				},
+			},
+		})
+	}
+
+	return volumes
 }
 
 // bluecatVolumes returns volumes needed for BlueCat provider
@@ -689,8 +1294,32 @@ func (b *externalDNSVolumeBuilder) bluecatVolumes() []corev1.Volume {
 	}
 }
 
-// addTXTPrefixFlag adds the txt prefix flag with default value
+// addTXTRegistryFlags adds the txt prefix flag with default value
 // needed if CNAME records are used: https://github.com/kubernetes-sigs/external-dns#note
-func addTXTPrefixFlag(args []string) []string {
-	return append(args, fmt.Sprintf("--txt-prefix=%s", defaultTXTRecordPrefix))
+// The prefix is replaced by the suffix if it's given in the registry options
+// along with the wildcard replacement, no flag is added if the ownership is not kept in the TXT records.
+func (b *externalDNSContainerBuilder) addTXTRegistryFlags(args []string) []string {
+	if !b.hasTXTRegistry() {
+		return args
+	}
+
+	txt := b.externalDNS.Spec.Registry.TXT
+	if txt == nil {
+		return append(args, fmt.Sprintf("--txt-prefix=%s", defaultTXTRecordPrefix))
+	}
+
+	switch {
+	case txt.Suffix != "":
+		args = append(args, fmt.Sprintf("--txt-suffix=%s", txt.Suffix))
+	case txt.Prefix != "":
+		args = append(args, fmt.Sprintf("--txt-prefix=%s", txt.Prefix))
+	default:
+		args = append(args, fmt.Sprintf("--txt-prefix=%s", defaultTXTRecordPrefix))
+	}
+
+	if txt.WildcardReplacement != "" {
+		args = append(args, fmt.Sprintf("--txt-wildcard-replacement=%s", txt.WildcardReplacement))
+	}
+
+	return args
 }
@@ -0,0 +1,166 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// authFailureLogsTailLines is the number of the last lines of the container logs
+	// which are scanned for the provider authentication failures.
+	authFailureLogsTailLines = 50
+	// dryRunPlanLogsTailLines is the number of the last lines of the container logs
+	// which are scanned for the changes planned in the dry run mode.
+	dryRunPlanLogsTailLines = 1000
+	// ownerMigrationLogsTailLines is the number of the last lines of the container logs
+	// which are scanned for the completion of the TXT owner migration.
+	ownerMigrationLogsTailLines = 100
+	// podLogsCachePeriod is the period during which the logs read from a container are reused:
+	// the status is computed on every reconciliation but ExternalDNS logs once per synchronization.
+	podLogsCachePeriod = time.Minute
+)
+
+var (
+	// logTimeRegexp matches the time of the log line of ExternalDNS in the text and JSON formats.
+	logTimeRegexp = regexp.MustCompile(`(?:^|[\s{,])"?time"?[=:]"([^"]+)"`)
+)
+
+// podLogsFunc returns the given number of the last lines of the logs of the given container.
+type podLogsFunc func(ctx context.Context, namespace, pod, container string, tailLines int64) (string, error)
+
+// newPodLogsFunc returns the function which reads the tail of the container logs using the given clientset.
+// The controller runtime client doesn't support the log subresource.
+func newPodLogsFunc(clientset kubernetes.Interface) podLogsFunc {
+	return func(ctx context.Context, namespace, pod, container string, tailLines int64) (string, error) {
+		raw, err := clientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+			Container: container,
+			TailLines: ptr.To(tailLines),
+		}).DoRaw(ctx)
+		if err != nil {
+			return "", err
+		}
+		return string(raw), nil
+	}
+}
+
+// podLogsCacheKey identifies the logs read from a container.
+type podLogsCacheKey struct {
+	namespace, pod, container string
+	tailLines                 int64
+}
+
+// podLogsCacheEntry is the result of the read of the logs.
+type podLogsCacheEntry struct {
+	logs    string
+	err     error
+	expires time.Time
+}
+
+// newCachedPodLogsFunc returns the function which reads the logs using the given function
+// at most once per the given period for the same container and the same number of lines.
+func newCachedPodLogsFunc(podLogs podLogsFunc, period time.Duration) podLogsFunc {
+	var mu sync.Mutex
+	cache := map[podLogsCacheKey]podLogsCacheEntry{}
+	return func(ctx context.Context, namespace, pod, container string, tailLines int64) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		now := clock.Now()
+		for key, entry := range cache {
+			if !now.Before(entry.expires) {
+				delete(cache, key)
+			}
+		}
+
+		key := podLogsCacheKey{namespace: namespace, pod: pod, container: container, tailLines: tailLines}
+		if entry, found := cache[key]; found {
+			return entry.logs, entry.err
+		}
+		logs, err := podLogs(ctx, namespace, pod, container, tailLines)
+		cache[key] = podLogsCacheEntry{logs: logs, err: err, expires: now.Add(period)}
+		return logs, err
+	}
+}
+
+// logLineTime returns the time of the given log line of ExternalDNS.
+// Returns false if the line has no time.
+func logLineTime(line string) (time.Time, bool) {
+	match := logTimeRegexp.FindStringSubmatch(line)
+	if match == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, match[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// normalizeLogLine returns the given log line of ExternalDNS in the text format.
+// The lines logged in the JSON format are converted to the text format of logrus:
+// the level and the message followed by the sorted fields, the time is omitted.
+// The same patterns match the logs of both formats.
+func normalizeLogLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") {
+		return line
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(trimmed), &fields); err != nil {
+		return line
+	}
+
+	keys := []string{}
+	for k := range fields {
+		if k != "level" && k != "msg" && k != "time" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "level=%s msg=%s", logFieldValue(fields["level"]), logFieldValue(fields["msg"]))
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%s", k, logFieldValue(fields[k]))
+	}
+	return b.String()
+}
+
+// logFieldValue returns the value of the log field as logged in the text format.
+func logFieldValue(v interface{}) string {
+	s := ""
+	if v != nil {
+		s = fmt.Sprint(v)
+	}
+	if s == "" || strings.ContainsAny(s, " \"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	utilclock "k8s.io/utils/clock"
+)
+
+func TestCachedPodLogsFunc(t *testing.T) {
+	defer func() { clock = utilclock.RealClock{} }()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock = fakeClock{now: now}
+
+	calls := 0
+	podLogs := newCachedPodLogsFunc(func(_ context.Context, _, _, _ string, _ int64) (string, error) {
+		calls++
+		return "logs", nil
+	}, time.Minute)
+
+	read := func(container string, tailLines int64) {
+		if logs, err := podLogs(context.TODO(), "external-dns", "pod", container, tailLines); err != nil || logs != "logs" {
+			t.Fatalf("unexpected result: %q, %v", logs, err)
+		}
+	}
+
+	read("external-dns", 50)
+	read("external-dns", 50)
+	if calls != 1 {
+		t.Errorf("expected the logs to be read once within the cache period, got %d reads", calls)
+	}
+
+	read("external-dns", 100)
+	read("external-dns-n2", 50)
+	if calls != 3 {
+		t.Errorf("expected the logs of different containers or tails to be read separately, got %d reads", calls)
+	}
+
+	clock = fakeClock{now: now.Add(time.Minute)}
+	read("external-dns", 50)
+	if calls != 4 {
+		t.Errorf("expected the logs to be read again after the cache period, got %d reads", calls)
+	}
+}
+
+func TestLogLineTime(t *testing.T) {
+	expected := time.Date(2024, 1, 1, 0, 0, 30, 0, time.UTC)
+	testCases := []struct {
+		name          string
+		line          string
+		expectedFound bool
+	}{
+		{
+			name:          "Text format",
+			line:          `time="2024-01-01T00:00:30Z" level=error msg="failed"`,
+			expectedFound: true,
+		},
+		{
+			name:          "JSON format",
+			line:          `{"level":"error","msg":"failed","time":"2024-01-01T00:00:30Z"}`,
+			expectedFound: true,
+		},
+		{
+			name: "No time",
+			line: `level=error msg="failed"`,
+		},
+		{
+			name: "Time in the message",
+			line: `level=error msg="failed at runtime=\"2024-01-01T00:00:30Z\""`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, found := logLineTime(tc.line)
+			if found != tc.expectedFound {
+				t.Fatalf("expected found %t, got %t", tc.expectedFound, found)
+			}
+			if found && !got.Equal(expected) {
+				t.Errorf("expected time %v, got %v", expected, got)
+			}
+		})
+	}
+}
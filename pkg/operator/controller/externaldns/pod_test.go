@@ -260,3 +260,64 @@ func TestDomainFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestLogLevel(t *testing.T) {
+	testCases := []struct {
+		name          string
+		level         v1beta1.ExternalDNSLogLevel
+		dryRun        bool
+		migrateFrom   string
+		cleanup       bool
+		expectedLevel string
+	}{
+		{
+			name:          "default level",
+			expectedLevel: "debug",
+		},
+		{
+			name:          "error level",
+			level:         v1beta1.LogLevelError,
+			expectedLevel: "error",
+		},
+		{
+			name:          "warn level raised to info in dry run mode",
+			level:         v1beta1.LogLevelWarn,
+			dryRun:        true,
+			expectedLevel: "info",
+		},
+		{
+			name:          "error level raised to info during owner migration",
+			level:         v1beta1.LogLevelError,
+			migrateFrom:   "previous-owner",
+			expectedLevel: "info",
+		},
+		{
+			name:          "debug level kept in dry run mode",
+			level:         v1beta1.LogLevelDebug,
+			dryRun:        true,
+			expectedLevel: "debug",
+		},
+		{
+			name:          "error level kept by cleanup",
+			level:         v1beta1.LogLevelError,
+			dryRun:        true,
+			cleanup:       true,
+			expectedLevel: "error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := &v1beta1.ExternalDNS{}
+			extDNS.Spec.Logging.Level = tc.level
+			extDNS.Spec.DryRun = tc.dryRun
+			if tc.migrateFrom != "" {
+				extDNS.Spec.Registry.TXT = &v1beta1.ExternalDNSTXTRegistryOptions{MigrateFromOwnerID: tc.migrateFrom}
+			}
+			b := &externalDNSContainerBuilder{externalDNS: extDNS, cleanup: tc.cleanup}
+			if got := b.logLevel(); got != tc.expectedLevel {
+				t.Errorf("expected log level %q, got %q", tc.expectedLevel, got)
+			}
+		})
+	}
+}
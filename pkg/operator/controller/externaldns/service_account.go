@@ -30,6 +30,32 @@ import (
 	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
 )
 
+const (
+	// Azure workload identity
+	// https://azure.github.io/azure-workload-identity/docs/topics/service-account-labels-and-annotations.html
+	azureWorkloadIdentityUseLabel           = "azure.workload.identity/use"
+	azureWorkloadIdentityClientIDAnnotation = "azure.workload.identity/client-id"
+	azureWorkloadIdentityTenantIDAnnotation = "azure.workload.identity/tenant-id"
+	// GKE workload identity
+	// https://cloud.google.com/kubernetes-engine/docs/how-to/workload-identity
+	gkeWorkloadIdentityServiceAccountAnnotation = "iam.gke.io/gcp-service-account"
+)
+
+var (
+	// workloadIdentityServiceAccountLabels are the service account labels managed by the operator,
+	// the rest of the labels is left to the users
+	workloadIdentityServiceAccountLabels = []string{
+		azureWorkloadIdentityUseLabel,
+	}
+	// workloadIdentityServiceAccountAnnotations are the service account annotations managed by the operator,
+	// the rest of the annotations is left to the users
+	workloadIdentityServiceAccountAnnotations = []string{
+		azureWorkloadIdentityClientIDAnnotation,
+		azureWorkloadIdentityTenantIDAnnotation,
+		gkeWorkloadIdentityServiceAccountAnnotation,
+	}
+)
+
 // ensureExternalDNSServiceAccount ensures that the externalDNS service account exists.
 func (r *reconciler) ensureExternalDNSServiceAccount(ctx context.Context, namespace string, externalDNS *operatorv1beta1.ExternalDNS) (bool, *corev1.ServiceAccount, error) {
 	nsName := types.NamespacedName{Namespace: namespace, Name: controller.ExternalDNSResourceName(externalDNS)}
@@ -52,6 +78,12 @@ func (r *reconciler) ensureExternalDNSServiceAccount(ctx context.Context, namesp
 		return r.currentExternalDNSServiceAccount(ctx, nsName)
 	}
 
+	if updated, err := r.updateExternalDNSServiceAccount(ctx, current, desired); err != nil {
+		return true, current, err
+	} else if updated {
+		return r.currentExternalDNSServiceAccount(ctx, nsName)
+	}
+
 	return true, current, nil
 }
 
@@ -68,13 +100,33 @@ func (r *reconciler) currentExternalDNSServiceAccount(ctx context.Context, nsNam
 }
 
 // desiredExternalDNSServiceAccount returns the desired serivce account resource.
+// The service account is labeled and annotated for the workload identity of the provider if needed.
 func desiredExternalDNSServiceAccount(namespace string, externalDNS *operatorv1beta1.ExternalDNS) *corev1.ServiceAccount {
-	return &corev1.ServiceAccount{
+	sa := &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
 			Name:      controller.ExternalDNSResourceName(externalDNS),
+			Labels:    controller.ExternalDNSOperandLabels(),
 		},
 	}
+
+	provider := externalDNS.Spec.Provider
+	if provider.Type == operatorv1beta1.ProviderTypeAzure && provider.Azure != nil &&
+		provider.Azure.CredentialsMode == operatorv1beta1.AzureCredentialsModeWorkloadIdentity && provider.Azure.WorkloadIdentity != nil {
+		sa.Labels[azureWorkloadIdentityUseLabel] = "true"
+		sa.Annotations = map[string]string{
+			azureWorkloadIdentityClientIDAnnotation: provider.Azure.WorkloadIdentity.ClientID,
+			azureWorkloadIdentityTenantIDAnnotation: provider.Azure.WorkloadIdentity.TenantID,
+		}
+	}
+	if provider.Type == operatorv1beta1.ProviderTypeGCP && provider.GCP != nil &&
+		provider.GCP.CredentialsMode == operatorv1beta1.GCPCredentialsModeGKEWorkloadIdentity && provider.GCP.GKEWorkloadIdentity != nil {
+		sa.Annotations = map[string]string{
+			gkeWorkloadIdentityServiceAccountAnnotation: provider.GCP.GKEWorkloadIdentity.ServiceAccountEmail,
+		}
+	}
+
+	return sa
 }
 
 // createExternalDNSServiceAccount creates the given service account using the reconciler's client.
@@ -86,3 +138,41 @@ func (r *reconciler) createExternalDNSServiceAccount(ctx context.Context, sa *co
 	r.log.Info("created externalDNS service account", "namespace", sa.Namespace, "name", sa.Name)
 	return nil
 }
+
+// updateExternalDNSServiceAccount updates the workload identity labels and annotations of the given service account.
+// Returns a Boolean indicating whether the service account was updated, and an error value.
+func (r *reconciler) updateExternalDNSServiceAccount(ctx context.Context, current, desired *corev1.ServiceAccount) (bool, error) {
+	updated := current.DeepCopy()
+	labelsChanged := syncManagedKeys(&updated.Labels, desired.Labels, workloadIdentityServiceAccountLabels)
+	annotationsChanged := syncManagedKeys(&updated.Annotations, desired.Annotations, workloadIdentityServiceAccountAnnotations)
+	if !labelsChanged && !annotationsChanged {
+		return false, nil
+	}
+	if err := r.client.Update(ctx, updated); err != nil {
+		return false, fmt.Errorf("failed to update externalDNS service account %s/%s: %w", updated.Namespace, updated.Name, err)
+	}
+	r.log.Info("updated externalDNS service account", "namespace", updated.Namespace, "name", updated.Name)
+	return true, nil
+}
+
+// syncManagedKeys sets the given keys of the current map to the desired values,
+// the keys which are not desired are removed. Returns true if the current map was changed.
+func syncManagedKeys(current *map[string]string, desired map[string]string, keys []string) bool {
+	changed := false
+	for _, key := range keys {
+		desiredValue, desiredExists := desired[key]
+		currentValue, currentExists := (*current)[key]
+		switch {
+		case desiredExists && (!currentExists || currentValue != desiredValue):
+			if *current == nil {
+				*current = map[string]string{}
+			}
+			(*current)[key] = desiredValue
+			changed = true
+		case !desiredExists && currentExists:
+			delete(*current, key)
+			changed = true
+		}
+	}
+	return changed
+}
@@ -35,12 +35,44 @@ import (
 )
 
 func TestEnsureExternalDNSServiceAccount(t *testing.T) {
+	azureWorkloadIdentity := test.ExternalDNS.DeepCopy()
+	azureWorkloadIdentity.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeAzure,
+		Azure: &operatorv1beta1.ExternalDNSAzureProviderOptions{
+			CredentialsMode: operatorv1beta1.AzureCredentialsModeWorkloadIdentity,
+			WorkloadIdentity: &operatorv1beta1.ExternalDNSAzureWorkloadIdentityOptions{
+				ClientID: "client",
+				TenantID: "tenant",
+			},
+		},
+	}
+	gkeWorkloadIdentity := test.ExternalDNS.DeepCopy()
+	gkeWorkloadIdentity.Spec.Provider = operatorv1beta1.ExternalDNSProvider{
+		Type: operatorv1beta1.ProviderTypeGCP,
+		GCP: &operatorv1beta1.ExternalDNSGCPProviderOptions{
+			CredentialsMode: operatorv1beta1.GCPCredentialsModeGKEWorkloadIdentity,
+			GKEWorkloadIdentity: &operatorv1beta1.ExternalDNSGCPGKEWorkloadIdentityOptions{
+				ServiceAccountEmail: "external-dns@my-project.iam.gserviceaccount.com",
+			},
+		},
+	}
+	ownerRefs := []metav1.OwnerReference{
+		{
+			APIVersion:         operatorv1beta1.GroupVersion.String(),
+			Kind:               "ExternalDNS",
+			Name:               test.ExternalDNS.Name,
+			Controller:         &test.TrueVar,
+			BlockOwnerDeletion: &test.TrueVar,
+		},
+	}
+
 	testCases := []struct {
-		name            string
-		existingObjects []runtime.Object
-		expectedExist   bool
-		expectedSA      corev1.ServiceAccount
-		errExpected     bool
+		name             string
+		inputExternalDNS *operatorv1beta1.ExternalDNS
+		existingObjects  []runtime.Object
+		expectedExist    bool
+		expectedSA       corev1.ServiceAccount
+		errExpected      bool
 	}{
 		{
 			name:            "Does not exist",
@@ -50,6 +82,7 @@ func TestEnsureExternalDNSServiceAccount(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      controller.ExternalDNSResourceName(test.ExternalDNS),
 					Namespace: test.OperandNamespace,
+					Labels:    controller.ExternalDNSOperandLabels(),
 					OwnerReferences: []metav1.OwnerReference{
 						{
 							APIVersion:         operatorv1beta1.GroupVersion.String(),
@@ -98,17 +131,125 @@ func TestEnsureExternalDNSServiceAccount(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:             "Does not exist with Azure workload identity",
+			inputExternalDNS: azureWorkloadIdentity,
+			existingObjects:  []runtime.Object{},
+			expectedExist:    true,
+			expectedSA: corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      controller.ExternalDNSResourceName(test.ExternalDNS),
+					Namespace: test.OperandNamespace,
+					Labels: map[string]string{
+						controller.ExternalDNSOperandLabel: "true",
+						"azure.workload.identity/use":      "true",
+					},
+					Annotations: map[string]string{
+						"azure.workload.identity/client-id": "client",
+						"azure.workload.identity/tenant-id": "tenant",
+					},
+					OwnerReferences: ownerRefs,
+				},
+			},
+		},
+		{
+			name:             "Does not exist with GKE workload identity",
+			inputExternalDNS: gkeWorkloadIdentity,
+			existingObjects:  []runtime.Object{},
+			expectedExist:    true,
+			expectedSA: corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      controller.ExternalDNSResourceName(test.ExternalDNS),
+					Namespace: test.OperandNamespace,
+					Labels:    controller.ExternalDNSOperandLabels(),
+					Annotations: map[string]string{
+						"iam.gke.io/gcp-service-account": "external-dns@my-project.iam.gserviceaccount.com",
+					},
+					OwnerReferences: ownerRefs,
+				},
+			},
+		},
+		{
+			name:             "Exists without Azure workload identity",
+			inputExternalDNS: azureWorkloadIdentity,
+			existingObjects: []runtime.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      controller.ExternalDNSResourceName(test.ExternalDNS),
+						Namespace: test.OperandNamespace,
+						Labels: map[string]string{
+							"user": "label",
+						},
+						Annotations: map[string]string{
+							"azure.workload.identity/client-id": "outdated",
+						},
+						OwnerReferences: ownerRefs,
+					},
+				},
+			},
+			expectedExist: true,
+			expectedSA: corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      controller.ExternalDNSResourceName(test.ExternalDNS),
+					Namespace: test.OperandNamespace,
+					Labels: map[string]string{
+						"user":                        "label",
+						"azure.workload.identity/use": "true",
+					},
+					Annotations: map[string]string{
+						"azure.workload.identity/client-id": "client",
+						"azure.workload.identity/tenant-id": "tenant",
+					},
+					OwnerReferences: ownerRefs,
+				},
+			},
+		},
+		{
+			name: "Exists with workload identity which is not needed anymore",
+			existingObjects: []runtime.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      controller.ExternalDNSResourceName(test.ExternalDNS),
+						Namespace: test.OperandNamespace,
+						Labels: map[string]string{
+							"azure.workload.identity/use": "true",
+						},
+						Annotations: map[string]string{
+							"azure.workload.identity/client-id": "client",
+							"azure.workload.identity/tenant-id": "tenant",
+							"user":                              "annotation",
+						},
+						OwnerReferences: ownerRefs,
+					},
+				},
+			},
+			expectedExist: true,
+			expectedSA: corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      controller.ExternalDNSResourceName(test.ExternalDNS),
+					Namespace: test.OperandNamespace,
+					Annotations: map[string]string{
+						"user": "annotation",
+					},
+					OwnerReferences: ownerRefs,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			extDNS := test.ExternalDNS
+			if tc.inputExternalDNS != nil {
+				extDNS = tc.inputExternalDNS
+			}
 			cl := fake.NewClientBuilder().WithRuntimeObjects(tc.existingObjects...).Build()
 			r := &reconciler{
 				client: cl,
 				scheme: test.Scheme,
 				log:    zap.New(zap.UseDevMode(true)),
 			}
-			gotExist, gotSA, err := r.ensureExternalDNSServiceAccount(context.TODO(), test.OperandNamespace, test.ExternalDNS)
+			gotExist, gotSA, err := r.ensureExternalDNSServiceAccount(context.TODO(), test.OperandNamespace, extDNS)
 			if err != nil {
 				if !tc.errExpected {
 					t.Fatalf("unexpected error received: %v", err)
@@ -3,13 +3,19 @@ package externaldnscontroller
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -25,27 +31,111 @@ const (
 	ExternalDNSDeploymentReplicasMinAvailableConditionType = "DeploymentReplicasMinAvailable"
 	ExternalDNSDeploymentReplicasAllAvailableConditionType = "DeploymentReplicasAllAvailable"
 	ExternalDNSCredentialsSecretExistsConditionType        = "CredentialsSecretExists"
+	ExternalDNSRecordsCleanedUpConditionType               = "RecordsCleanedUp"
+	ExternalDNSProviderAuthenticatedConditionType          = "ProviderAuthenticated"
+	ExternalDNSPolicyRestrictedConditionType               = "PolicyRestricted"
+	ExternalDNSDryRunConditionType                         = "DryRun"
+	ExternalDNSOwnerMigrationConditionType                 = "OwnerMigration"
+	ExternalDNSConflictingConditionType                    = "Conflicting"
+	ExternalDNSSuspendedConditionType                      = "Suspended"
+	// maxAuthFailureMessageLength is the maximum length of the container message
+	// which is copied to the condition about the provider authentication failure.
+	maxAuthFailureMessageLength = 256
+	// authFailureSyncIntervals is the number of the synchronization intervals after which
+	// the authentication failure is considered as resolved if it's not logged again:
+	// ExternalDNS logs the error on every failed synchronization.
+	authFailureSyncIntervals = 3
+	// defaultSyncInterval is the interval between two synchronizations used by ExternalDNS by default.
+	defaultSyncInterval = 60 * time.Second
 )
 
+// providerAuthFailurePatterns are the fragments of the errors returned by DNS providers
+// when the credentials are invalid or don't grant the needed permissions.
+// The fragments are matched case-sensitively in the errors logged by ExternalDNS for the provider of the instance.
+var providerAuthFailurePatterns = map[operatorv1beta1.ExternalDNSProviderType][]string{
+	operatorv1beta1.ProviderTypeAWS: {
+		"InvalidClientTokenId",
+		"InvalidAccessKeyId",
+		"SignatureDoesNotMatch",
+		"UnrecognizedClientException",
+		"ExpiredToken",
+		"AccessDenied",
+		"WebIdentityErr",
+		"NoCredentialProviders",
+	},
+	operatorv1beta1.ProviderTypeAzure: {
+		"AADSTS",
+		"AuthorizationFailed",
+		"invalid_client",
+	},
+	operatorv1beta1.ProviderTypeGCP: {
+		"invalid_grant",
+		"could not find default credentials",
+		"googleapi: Error 401",
+		"googleapi: Error 403",
+	},
+	operatorv1beta1.ProviderTypeCloudflare: {
+		"Authentication error (10000)",
+		"Invalid request headers (6003)",
+	},
+	operatorv1beta1.ProviderTypeRFC2136: {
+		"dns: bad signature",
+		"NOTAUTH",
+	},
+	operatorv1beta1.ProviderTypeInfoblox: {
+		"401 Unauthorized",
+		"403 Forbidden",
+	},
+	operatorv1beta1.ProviderTypeBlueCat: {
+		"401 Unauthorized",
+		"403 Forbidden",
+	},
+	operatorv1beta1.ProviderTypeWebhook: {
+		"code 401",
+		"code 403",
+	},
+}
+
+// errorLogLevelRegexp matches the log lines of ExternalDNS logged at the error or fatal level.
+var errorLogLevelRegexp = regexp.MustCompile(`(^|\s)level=(error|fatal)(\s|$)`)
+
+// deploymentConditionTypes are the types of the conditions computed from the operand deployment,
+// they are not reported while the instance is suspended.
+var deploymentConditionTypes = []string{
+	ExternalDNSDeploymentAvailableConditionType,
+	ExternalDNSDeploymentReplicasMinAvailableConditionType,
+	ExternalDNSDeploymentReplicasAllAvailableConditionType,
+	ExternalDNSPodsScheduledConditionType,
+	ExternalDNSProviderAuthenticatedConditionType,
+}
+
 // clock is to enable unit testing
 var clock utilclock.WithTickerAndDelayedExecution = utilclock.RealClock{}
 
 // updateExternalDNSStatus updates the status of the given externaldns instance with
-// the status of the operand deployment and the credentials secret.
-func (r *reconciler) updateExternalDNSStatus(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, currentDeployment *appsv1.Deployment, secretExists bool) error {
+// the status of the operand deployment, the credentials secret and the given additional conditions.
+func (r *reconciler) updateExternalDNSStatus(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, currentDeployment *appsv1.Deployment, secretExists bool, conditions ...metav1.Condition) error {
 	extDNSWithStatus := externalDNS.DeepCopy()
 	// deployment
-	if currentDeployment != nil {
+	if externalDNS.Spec.Suspend {
+		// the deployment is scaled down deliberately, the last zone statuses are kept
+		extDNSWithStatus.Status.Conditions = removeConditions(extDNSWithStatus.Status.Conditions, deploymentConditionTypes...)
+	} else if currentDeployment != nil {
 		extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions,
 			computeDeploymentAvailableCondition(currentDeployment),
 			computeMinReplicasCondition(currentDeployment),
 			computeAllReplicasCondition(currentDeployment),
 			computeDeploymentPodsScheduledCondition(ctx, r.client, currentDeployment),
+			computeProviderAuthenticatedCondition(ctx, r.client, r.podLogs, externalDNS, currentDeployment),
 		)
+		extDNSWithStatus.Status.ZoneStatuses = computeZoneStatuses(ctx, r.client, r.scrapeMetrics, externalDNS, currentDeployment)
 	}
 	// credentials secret
 	secretExistsCond := createCredentialsSecretExistsCondition()
-	if !secretExists {
+	if !secretExists && workloadIdentityWithoutSecret(externalDNS) {
+		secretExistsCond.Reason = "SecretNotNeeded"
+		secretExistsCond.Message = "No credentials secret is needed, the workload identity is used."
+	} else if !secretExists {
 		secretExistsCond.Status = metav1.ConditionFalse
 		secretExistsCond.Reason = "SecretNotFound"
 		// we don't show the name of the secret deliberately
@@ -53,7 +143,8 @@ func (r *reconciler) updateExternalDNSStatus(ctx context.Context, externalDNS *o
 		// by showing this condition we invite the user to check the logs and see the full picture
 		secretExistsCond.Message = "The credentials secret not found."
 	}
-	extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, secretExistsCond)
+	extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, secretExistsCond, computePolicyRestrictedCondition(externalDNS), computeSuspendedCondition(externalDNS))
+	extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, conditions...)
 
 	extDNSWithStatus.Status.ObservedGeneration = extDNSWithStatus.Generation
 	extDNSWithStatus.Status.Zones = extDNSWithStatus.Spec.Zones
@@ -63,6 +154,90 @@ func (r *reconciler) updateExternalDNSStatus(ctx context.Context, externalDNS *o
 	return nil
 }
 
+// updateExternalDNSCleanupStatus updates the status of the given externaldns instance
+// with the given condition of the DNS records cleanup.
+func (r *reconciler) updateExternalDNSCleanupStatus(ctx context.Context, externalDNS *operatorv1beta1.ExternalDNS, cleanupCond metav1.Condition) error {
+	extDNSWithStatus := externalDNS.DeepCopy()
+	extDNSWithStatus.Status.Conditions = mergeConditions(extDNSWithStatus.Status.Conditions, cleanupCond)
+	if !externalDNSStatusesEqual(extDNSWithStatus.Status, externalDNS.Status) {
+		return r.client.Status().Update(ctx, extDNSWithStatus)
+	}
+	return nil
+}
+
+// computePolicyRestrictedCondition returns an externalDNS condition which notes
+// whether the policy prevents ExternalDNS from updating or deleting the records.
+func computePolicyRestrictedCondition(externalDNS *operatorv1beta1.ExternalDNS) metav1.Condition {
+	switch externalDNS.Spec.Policy {
+	case operatorv1beta1.PolicyUpsertOnly:
+		return metav1.Condition{
+			Type:    ExternalDNSPolicyRestrictedConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "UpsertOnlyPolicy",
+			Message: "The DNS records are created and updated but never deleted",
+		}
+	case operatorv1beta1.PolicyCreateOnly:
+		return metav1.Condition{
+			Type:    ExternalDNSPolicyRestrictedConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "CreateOnlyPolicy",
+			Message: "The DNS records are created but never updated or deleted",
+		}
+	}
+	return metav1.Condition{
+		Type:    ExternalDNSPolicyRestrictedConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  "SyncPolicy",
+		Message: "The DNS records are created, updated and deleted to match the sources",
+	}
+}
+
+// computeSuspendedCondition returns an externalDNS condition which notes
+// whether the operand is scaled down because the instance is suspended.
+func computeSuspendedCondition(externalDNS *operatorv1beta1.ExternalDNS) metav1.Condition {
+	if externalDNS.Spec.Suspend {
+		return metav1.Condition{
+			Type:    ExternalDNSSuspendedConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Suspended",
+			Message: "The deployment is scaled down to zero replicas, the DNS records are not reconciled",
+		}
+	}
+	return metav1.Condition{
+		Type:    ExternalDNSSuspendedConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NotSuspended",
+		Message: "The DNS records are reconciled",
+	}
+}
+
+// computeRecordsCleanedUpCondition returns an externalDNS condition based on the status of the cleanup job.
+func computeRecordsCleanedUpCondition(job *batchv1.Job) metav1.Condition {
+	finished, succeeded := cleanupJobFinished(job)
+	switch {
+	case !finished:
+		return metav1.Condition{
+			Type:    ExternalDNSRecordsCleanedUpConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "CleanupInProgress",
+			Message: fmt.Sprintf("The cleanup job %s is in progress", job.Name),
+		}
+	case !succeeded:
+		return metav1.Condition{
+			Type:    ExternalDNSRecordsCleanedUpConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "CleanupFailed",
+			Message: fmt.Sprintf("The cleanup job %s failed, some DNS records may be left behind", job.Name),
+		}
+	}
+	return metav1.Condition{
+		Type:    ExternalDNSRecordsCleanedUpConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "CleanupSucceeded",
+		Message: fmt.Sprintf("The cleanup job %s completed", job.Name),
+	}
+}
+
 // computeDeploymentAvailableCondition returns an externalDNS condition based on the deployment status & its conditions
 func computeDeploymentAvailableCondition(deployment *appsv1.Deployment) metav1.Condition {
 	for _, cond := range deployment.Status.Conditions {
@@ -249,6 +424,110 @@ func computeDeploymentPodsScheduledCondition(ctx context.Context, cl client.Clie
 
 }
 
+// computeProviderAuthenticatedCondition lists the pods matching the namespace and the label selector of the deployment
+// and looks for the authentication failures in the termination messages of their crashing containers.
+// ExternalDNS exits with the error as the termination message when the provider fails at the startup,
+// however the errors from the synchronization loop don't make it exit: the tail of the logs of the running containers
+// is scanned too if podLogs is given. A failure from the logs is cleared by a later synchronization
+// which found the records up to date or once it's not repeated for a few synchronization intervals.
+// Returns condition false with AuthenticationFailed reason if any container failed to authenticate.
+func computeProviderAuthenticatedCondition(ctx context.Context, cl client.Client, podLogs podLogsFunc, externalDNS *operatorv1beta1.ExternalDNS, deployment *appsv1.Deployment) metav1.Condition {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return createProviderAuthenticatedUnknownCondition("InvalidLabelSelector", "Deployment has an invalid label selector.")
+	}
+	pods, err := getFilteredPodsList(ctx, cl, deployment.Namespace, selector)
+	if err != nil {
+		return createProviderAuthenticatedUnknownCondition("ProviderAuthenticatedUnknown", "Unable to list pods: "+err.Error())
+	}
+	patterns := providerAuthFailurePatterns[externalDNS.Spec.Provider.Type]
+	maxAge := authFailureSyncIntervals * externalDNSSyncInterval(externalDNS)
+	// sort pods so that the result is deterministic
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if isMetricsProxyContainer(cs.Name) {
+				continue
+			}
+			line := ""
+			if cs.State.Running == nil {
+				// the termination message is relevant only until the container runs again
+				for _, terminated := range []*corev1.ContainerStateTerminated{cs.State.Terminated, cs.LastTerminationState.Terminated} {
+					if terminated != nil && line == "" {
+						line = findProviderAuthFailure(terminated.Message, patterns, 0)
+					}
+				}
+			} else if podLogs != nil {
+				// the logs are the best effort source:
+				// failing to read them doesn't make the condition unknown
+				if logs, err := podLogs(ctx, pod.Namespace, pod.Name, cs.Name, authFailureLogsTailLines); err == nil {
+					line = findProviderAuthFailure(logs, patterns, maxAge)
+				}
+			}
+			if line != "" {
+				return metav1.Condition{
+					Type:    ExternalDNSProviderAuthenticatedConditionType,
+					Status:  metav1.ConditionFalse,
+					Reason:  operatorv1beta1.ExternalDNSProviderAuthFailedReasonType,
+					Message: fmt.Sprintf("Container %q of pod %q failed to authenticate with the DNS provider: %s", cs.Name, pod.Name, line),
+				}
+			}
+		}
+	}
+	return metav1.Condition{
+		Type:    ExternalDNSProviderAuthenticatedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "NoAuthenticationFailures",
+		Message: "No authentication failures detected",
+	}
+}
+
+// findProviderAuthFailure returns the last error line of the given logs which matches any of the given patterns.
+// The failure is discarded if a later synchronization found the records up to date
+// or if the line was logged longer than maxAge ago, the age is not checked if maxAge is zero.
+// The line is truncated to the maximum length allowed in the condition message.
+func findProviderAuthFailure(logs string, patterns []string, maxAge time.Duration) string {
+	failure := ""
+	for _, line := range strings.Split(logs, "\n") {
+		normalized := normalizeLogLine(line)
+		if strings.Contains(normalized, recordsUpToDateLogMessage) {
+			failure = ""
+			continue
+		}
+		if !errorLogLevelRegexp.MatchString(normalized) || !containsAny(normalized, patterns) {
+			continue
+		}
+		if logged, ok := logLineTime(line); ok && maxAge > 0 && clock.Since(logged) > maxAge {
+			continue
+		}
+		failure = strings.TrimSpace(line)
+	}
+	if len(failure) > maxAuthFailureMessageLength {
+		failure = failure[:maxAuthFailureMessageLength] + "..."
+	}
+	return failure
+}
+
+// containsAny returns true if the given string contains any of the given substrings.
+func containsAny(s string, substrs []string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// externalDNSSyncInterval returns the interval between two synchronizations of the given externalDNS.
+func externalDNSSyncInterval(externalDNS *operatorv1beta1.ExternalDNS) time.Duration {
+	if externalDNS.Spec.IntervalSeconds > 0 {
+		return time.Duration(externalDNS.Spec.IntervalSeconds) * time.Second
+	}
+	return defaultSyncInterval
+}
+
 // mergeConditions updates the conditions list with new conditions.
 // Each condition is added if no condition of the same type already exists.
 // Otherwise, the condition is merged with the existing condition of the same type.
@@ -274,6 +553,17 @@ func mergeConditions(conditions []metav1.Condition, updates ...metav1.Condition)
 	return conditions
 }
 
+// removeConditions returns the conditions list without the conditions of the given types.
+func removeConditions(conditions []metav1.Condition, types ...string) []metav1.Condition {
+	var kept []metav1.Condition
+	for _, cond := range conditions {
+		if !slices.Contains(types, cond.Type) {
+			kept = append(kept, cond)
+		}
+	}
+	return kept
+}
+
 func conditionChanged(a, b metav1.Condition) bool {
 	return a.Status != b.Status || a.Reason != b.Reason || a.Message != b.Message
 }
@@ -285,6 +575,9 @@ func externalDNSStatusesEqual(a, b operatorv1beta1.ExternalDNSStatus) bool {
 	if !zonesEqual(a.Zones, b.Zones) {
 		return false
 	}
+	if !equality.Semantic.DeepEqual(a.ZoneStatuses, b.ZoneStatuses) {
+		return false
+	}
 	return conditionsEqual(a.Conditions, b.Conditions)
 }
 
@@ -313,6 +606,15 @@ func createDeploymentAvailabilityUnknownCondition() metav1.Condition {
 	}
 }
 
+func createProviderAuthenticatedUnknownCondition(reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    ExternalDNSProviderAuthenticatedConditionType,
+		Status:  metav1.ConditionUnknown,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
 func createPodsScheduledUnknownCondition(reason, message string) metav1.Condition {
 	return metav1.Condition{
 		Type:    ExternalDNSPodsScheduledConditionType,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	utilclock "k8s.io/utils/clock"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
@@ -426,9 +428,266 @@ func TestGetPodsList(t *testing.T) {
 	}
 }
 
+func TestComputeProviderAuthenticatedCondition(t *testing.T) {
+	awsAuthFailure := `time="2024-01-01T00:00:00Z" level=error msg="failed to list hosted zones: operation error Route 53: ListHostedZones, https response error StatusCode: 403, api error InvalidClientTokenId: The security token included in the request is invalid."`
+	awsAuthFailureJSON := `{"level":"error","msg":"failed to list hosted zones: api error InvalidClientTokenId: The security token included in the request is invalid.","time":"2024-01-01T00:00:00Z"}`
+	runningContainer := corev1.ContainerStatus{
+		Name:  "external-dns",
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+	testCases := []struct {
+		name               string
+		provider           operatorv1beta1.ExternalDNSProviderType
+		now                time.Time
+		existingDeployment appsv1.Deployment
+		existingPods       []corev1.Pod
+		podLogs            podLogsFunc
+		expectedResult     metav1.Condition
+	}{
+		{
+			name:               "No failures should return ConditionTrue",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods: []corev1.Pod{
+				fakePodWithContainerStatus("pod", "external-dns-operator", corev1.ContainerStatus{
+					Name:  "external-dns",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}),
+			},
+			podLogs: fakePodLogs(`time="2024-01-01T00:00:00Z" level=info msg="All records are already up to date"`, nil),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "NoAuthenticationFailures",
+				Message: "No authentication failures detected",
+			},
+		},
+		{
+			name:               "Deployment selector empty or invalid should return ConditionUnknown",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, ""),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionUnknown,
+				Reason:  "InvalidLabelSelector",
+				Message: "Deployment has an invalid label selector.",
+			},
+		},
+		{
+			name:               "Auth failure in last termination message should return ConditionFalse",
+			provider:           operatorv1beta1.ProviderTypeAzure,
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionFalse, 1, "25%", "25%", 0, "external-dns-operator"),
+			existingPods: []corev1.Pod{
+				fakePodWithContainerStatus("pod", "external-dns-operator", corev1.ContainerStatus{
+					Name:  "external-dns",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  "level=info msg=\"Instantiating new Kubernetes client\"\nlevel=fatal msg=\"AADSTS7000215: Invalid client secret provided.\"\n",
+					}},
+				}),
+			},
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  operatorv1beta1.ExternalDNSProviderAuthFailedReasonType,
+				Message: `Container "external-dns" of pod "pod" failed to authenticate with the DNS provider: level=fatal msg="AADSTS7000215: Invalid client secret provided."`,
+			},
+		},
+		{
+			name:               "Auth failure in logs of running container should return ConditionFalse",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods: []corev1.Pod{
+				fakePodWithContainerStatus("pod", "external-dns-operator", corev1.ContainerStatus{
+					Name:  "external-dns",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}),
+			},
+			podLogs: fakePodLogs(awsAuthFailure, nil),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  operatorv1beta1.ExternalDNSProviderAuthFailedReasonType,
+				Message: `Container "external-dns" of pod "pod" failed to authenticate with the DNS provider: ` + awsAuthFailure,
+			},
+		},
+		{
+			name:               "Failure to read logs should return ConditionTrue",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods: []corev1.Pod{
+				fakePodWithContainerStatus("pod", "external-dns-operator", corev1.ContainerStatus{
+					Name:  "external-dns",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				}),
+			},
+			podLogs: fakePodLogs("", errors.NewForbidden(corev1.Resource("pods"), "pod", nil)),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "NoAuthenticationFailures",
+				Message: "No authentication failures detected",
+			},
+		},
+		{
+			name:               "Auth failure in JSON logs should return ConditionFalse",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods:       []corev1.Pod{fakePodWithContainerStatus("pod", "external-dns-operator", runningContainer)},
+			podLogs:            fakePodLogs(awsAuthFailureJSON, nil),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  operatorv1beta1.ExternalDNSProviderAuthFailedReasonType,
+				Message: `Container "external-dns" of pod "pod" failed to authenticate with the DNS provider: ` + awsAuthFailureJSON,
+			},
+		},
+		{
+			name:               "Auth failure pattern below error level should return ConditionTrue",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods:       []corev1.Pod{fakePodWithContainerStatus("pod", "external-dns-operator", runningContainer)},
+			podLogs:            fakePodLogs(`time="2024-01-01T00:00:00Z" level=info msg="Skipping record external-dns-accessdenied.example.com: AccessDenied"`, nil),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "NoAuthenticationFailures",
+				Message: "No authentication failures detected",
+			},
+		},
+		{
+			name:               "Auth failure pattern of another provider should return ConditionTrue",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods:       []corev1.Pod{fakePodWithContainerStatus("pod", "external-dns-operator", runningContainer)},
+			podLogs:            fakePodLogs(`time="2024-01-01T00:00:00Z" level=error msg="failed to sync: AADSTS7000215: Invalid client secret provided."`, nil),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "NoAuthenticationFailures",
+				Message: "No authentication failures detected",
+			},
+		},
+		{
+			name:               "Auth failure followed by successful sync should return ConditionTrue",
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods:       []corev1.Pod{fakePodWithContainerStatus("pod", "external-dns-operator", runningContainer)},
+			podLogs:            fakePodLogs(awsAuthFailure+"\n"+`time="2024-01-01T00:00:30Z" level=info msg="All records are already up to date"`, nil),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "NoAuthenticationFailures",
+				Message: "No authentication failures detected",
+			},
+		},
+		{
+			name:               "Auth failure not repeated for a few sync intervals should return ConditionTrue",
+			now:                time.Date(2024, 1, 1, 0, 10, 0, 0, time.UTC),
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods:       []corev1.Pod{fakePodWithContainerStatus("pod", "external-dns-operator", runningContainer)},
+			podLogs:            fakePodLogs(awsAuthFailure, nil),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "NoAuthenticationFailures",
+				Message: "No authentication failures detected",
+			},
+		},
+		{
+			name:               "Auth failure in last termination message of running container should return ConditionTrue",
+			provider:           operatorv1beta1.ProviderTypeAzure,
+			existingDeployment: fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator"),
+			existingPods: []corev1.Pod{
+				fakePodWithContainerStatus("pod", "external-dns-operator", corev1.ContainerStatus{
+					Name:  "external-dns",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  "level=fatal msg=\"AADSTS7000215: Invalid client secret provided.\"\n",
+					}},
+				}),
+			},
+			podLogs: fakePodLogs(`time="2024-01-01T00:00:00Z" level=info msg="All records are already up to date"`, nil),
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSProviderAuthenticatedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "NoAuthenticationFailures",
+				Message: "No authentication failures detected",
+			},
+		},
+	}
+
+	defer func() { clock = utilclock.RealClock{} }()
+	for _, tc := range testCases {
+		fakeObjects := append(fakeRuntimeObjectFromPodList(tc.existingPods), &tc.existingDeployment)
+		cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(fakeObjects...).Build()
+		t.Run(tc.name, func(t *testing.T) {
+			now := tc.now
+			if now.IsZero() {
+				now = time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)
+			}
+			clock = fakeClock{now: now}
+			provider := tc.provider
+			if provider == "" {
+				provider = operatorv1beta1.ProviderTypeAWS
+			}
+			extDNS := &operatorv1beta1.ExternalDNS{Spec: operatorv1beta1.ExternalDNSSpec{Provider: operatorv1beta1.ExternalDNSProvider{Type: provider}}}
+			cond := computeProviderAuthenticatedCondition(context.TODO(), cl, tc.podLogs, extDNS, &tc.existingDeployment)
+			if diff := cmp.Diff(tc.expectedResult, cond, ignoreTimeOpt); diff != "" {
+				t.Errorf("expected condition %v; got condition %v: \n %s", tc.expectedResult, cond, diff)
+			}
+		})
+	}
+}
+
+func TestComputePolicyRestrictedCondition(t *testing.T) {
+	testCases := []struct {
+		name           string
+		policy         operatorv1beta1.ExternalDNSPolicy
+		expectedResult metav1.Condition
+	}{
+		{
+			name:           "Default policy should return ConditionFalse",
+			expectedResult: fakeSyncPolicyCondition(),
+		},
+		{
+			name:           "Sync policy should return ConditionFalse",
+			policy:         operatorv1beta1.PolicySync,
+			expectedResult: fakeSyncPolicyCondition(),
+		},
+		{
+			name:   "UpsertOnly policy should return ConditionTrue",
+			policy: operatorv1beta1.PolicyUpsertOnly,
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSPolicyRestrictedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "UpsertOnlyPolicy",
+				Message: "The DNS records are created and updated but never deleted",
+			},
+		},
+		{
+			name:   "CreateOnly policy should return ConditionTrue",
+			policy: operatorv1beta1.PolicyCreateOnly,
+			expectedResult: metav1.Condition{
+				Type:    ExternalDNSPolicyRestrictedConditionType,
+				Status:  metav1.ConditionTrue,
+				Reason:  "CreateOnlyPolicy",
+				Message: "The DNS records are created but never updated or deleted",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := fakeExternalDNS()
+			extDNS.Spec.Policy = tc.policy
+			cond := computePolicyRestrictedCondition(extDNS)
+			if diff := cmp.Diff(tc.expectedResult, cond, ignoreTimeOpt); diff != "" {
+				t.Errorf("expected condition %v; got condition %v: \n %s", tc.expectedResult, cond, diff)
+			}
+		})
+	}
+}
+
 func TestUpdateExternalDNSStatus(t *testing.T) {
 	aDeployment := fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 8, "25%", "25%", 8, "external-dns-operator")
 	anExternalDNS := fakeExternalDNS()
+	aSuspendedExternalDNS := fakeSuspendedExternalDNS()
+	aWebIdentityExternalDNS := fakeWebIdentityExternalDNS()
 	namespacedName := types.NamespacedName{
 		Namespace: "",
 		Name:      test.Name,
@@ -459,6 +718,23 @@ func TestUpdateExternalDNSStatus(t *testing.T) {
 			errExpected:     false,
 			expectedResult:  fakeExternalDNSWithStatusSecretMissing(),
 		},
+		{
+			name:            "Credentials secret not needed with web identity",
+			existingObjects: append(fakeRuntimeObjectFromPodList(fakePodList()), aWebIdentityExternalDNS),
+			existingExtDNS:  aWebIdentityExternalDNS,
+			secretExists:    false,
+			errExpected:     false,
+			expectedResult:  fakeExternalDNSWithStatusSecretNotNeeded(),
+		},
+		{
+			name:               "Suspended instance drops the deployment conditions",
+			existingDeployment: &aDeployment,
+			existingObjects:    append(fakeRuntimeObjectFromPodList(fakePodList()), &aDeployment, aSuspendedExternalDNS),
+			existingExtDNS:     aSuspendedExternalDNS,
+			secretExists:       true,
+			errExpected:        false,
+			expectedResult:     fakeExternalDNSWithStatusSuspended(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -617,11 +893,20 @@ func fakeExternalDNSWithStatus() operatorv1beta1.ExternalDNS {
 		Reason:  "SecretFound",
 		Message: "The credentials secret has been found.",
 	}
+	condProviderAuthenticated := metav1.Condition{
+		Type:    ExternalDNSProviderAuthenticatedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "NoAuthenticationFailures",
+		Message: "No authentication failures detected",
+	}
 	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condDeploymentAvailable)
 	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condAllReplicaAvailable)
 	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condMinReplicaAvailable)
 	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condPodScheduled)
 	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condSecretExists)
+	extDNS.Status.Conditions = append(extDNS.Status.Conditions, condProviderAuthenticated)
+	extDNS.Status.Conditions = append(extDNS.Status.Conditions, fakeSyncPolicyCondition())
+	extDNS.Status.Conditions = append(extDNS.Status.Conditions, fakeSuspendedCondition(false))
 
 	return *extDNS
 }
@@ -633,11 +918,79 @@ func fakeExternalDNSWithStatusSecretMissing() operatorv1beta1.ExternalDNS {
 		Status:  metav1.ConditionFalse,
 		Reason:  "SecretNotFound",
 		Message: "The credentials secret not found.",
-	})
+	}, fakeSyncPolicyCondition(), fakeSuspendedCondition(false))
+
+	return *extDNS
+}
+
+func fakeWebIdentityExternalDNS() *operatorv1beta1.ExternalDNS {
+	extDNS := fakeExternalDNS()
+	extDNS.Spec.Provider.AWS = &operatorv1beta1.ExternalDNSAWSProviderOptions{
+		CredentialsMode: operatorv1beta1.AWSCredentialsModeWebIdentity,
+		WebIdentity: &operatorv1beta1.ExternalDNSAWSWebIdentityOptions{
+			RoleARN: "arn:aws:iam::123456789012:role/external-dns",
+		},
+	}
+	return extDNS
+}
+
+func fakeExternalDNSWithStatusSecretNotNeeded() operatorv1beta1.ExternalDNS {
+	extDNS := fakeWebIdentityExternalDNS()
+	extDNS.Status.Conditions = append(extDNS.Status.Conditions, metav1.Condition{
+		Type:    ExternalDNSCredentialsSecretExistsConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "SecretNotNeeded",
+		Message: "No credentials secret is needed, the workload identity is used.",
+	}, fakeSyncPolicyCondition(), fakeSuspendedCondition(false))
+
+	return *extDNS
+}
+
+func fakeSuspendedExternalDNS() *operatorv1beta1.ExternalDNS {
+	extDNS := fakeExternalDNSWithStatus()
+	extDNS.Spec.Suspend = true
+	return &extDNS
+}
+
+func fakeExternalDNSWithStatusSuspended() operatorv1beta1.ExternalDNS {
+	extDNS := fakeExternalDNS()
+	extDNS.Spec.Suspend = true
+	extDNS.Status.Conditions = append(extDNS.Status.Conditions, metav1.Condition{
+		Type:    ExternalDNSCredentialsSecretExistsConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "SecretFound",
+		Message: "The credentials secret has been found.",
+	}, fakeSyncPolicyCondition(), fakeSuspendedCondition(true))
 
 	return *extDNS
 }
 
+func fakeSuspendedCondition(suspended bool) metav1.Condition {
+	if suspended {
+		return metav1.Condition{
+			Type:    ExternalDNSSuspendedConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  "Suspended",
+			Message: "The deployment is scaled down to zero replicas, the DNS records are not reconciled",
+		}
+	}
+	return metav1.Condition{
+		Type:    ExternalDNSSuspendedConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NotSuspended",
+		Message: "The DNS records are reconciled",
+	}
+}
+
+func fakeSyncPolicyCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:    ExternalDNSPolicyRestrictedConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  "SyncPolicy",
+		Message: "The DNS records are created, updated and deleted to match the sources",
+	}
+}
+
 func fakePod(name string, namespace string, selectorLabel string, status corev1.ConditionStatus, reason string) corev1.Pod {
 	return corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -656,3 +1009,29 @@ func fakePod(name string, namespace string, selectorLabel string, status corev1.
 		},
 	}
 }
+
+func fakePodWithContainerStatus(name string, selectorLabel string, status corev1.ContainerStatus) corev1.Pod {
+	pod := fakePod(name, "external-dns-operator", selectorLabel, corev1.ConditionTrue, "Scheduled")
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{status}
+	return pod
+}
+
+// fakeClock is the clock which always returns the given time.
+type fakeClock struct {
+	utilclock.RealClock
+	now time.Time
+}
+
+func (c fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c fakeClock) Since(t time.Time) time.Duration {
+	return c.now.Sub(t)
+}
+
+func fakePodLogs(logs string, err error) podLogsFunc {
+	return func(_ context.Context, _, _, _ string, _ int64) (string, error) {
+		return logs, err
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+)
+
+const (
+	// lastSyncTimestampMetric is the time of the last successful synchronization of ExternalDNS.
+	lastSyncTimestampMetric = "external_dns_controller_last_sync_timestamp_seconds"
+	// registryEndpointsMetric is the number of the endpoints owned by ExternalDNS in the registry.
+	registryEndpointsMetric = "external_dns_registry_endpoints_total"
+	// maxZoneErrorLength is the maximum length of the error reported in the zone status.
+	maxZoneErrorLength = 256
+	// metricsNotExposedError is reported when the metrics are only exposed on the loopback interface of the pod.
+	metricsNotExposedError = "the metrics are not scraped: spec.metrics.exposure must be KubeRBACProxy to report the last sync time and the managed records"
+	// metricsNotVerifiableError is reported when the certificate of kube-rbac-proxy cannot be verified.
+	metricsNotVerifiableError = "the metrics are not scraped: the certificate of kube-rbac-proxy can only be verified on OpenShift"
+)
+
+// computeZoneStatuses returns the synchronization status of the zones served by the containers of the given deployment.
+// The synchronization time and the number of the managed records are scraped from the metrics
+// only if they are exposed by kube-rbac-proxy on OpenShift, otherwise the reason is reported as the error of the zone.
+// The last known values are kept if the metrics cannot be scraped.
+func computeZoneStatuses(ctx context.Context, cl client.Client, scrape scrapeMetricsFunc, externalDNS *operatorv1beta1.ExternalDNS, deployment *appsv1.Deployment) []operatorv1beta1.ExternalDNSZoneStatus {
+	previous := map[string]operatorv1beta1.ExternalDNSZoneStatus{}
+	for _, zs := range externalDNS.Status.ZoneStatuses {
+		previous[zs.ContainerName] = zs
+	}
+
+	pods, podsErr := runningExternalDNSPods(ctx, cl, deployment)
+
+	// the local metrics listen on the loopback interface of the pod
+	// and the self-signed certificate of kube-rbac-proxy cannot be verified outside of OpenShift
+	notScrapedError := ""
+	if externalDNS.Spec.Metrics.Exposure != operatorv1beta1.MetricsExposureKubeRBACProxy {
+		notScrapedError = metricsNotExposedError
+	} else if scrape == nil {
+		notScrapedError = metricsNotVerifiableError
+	}
+	serviceName := controller.ExternalDNSMetricsServiceName(deployment.Namespace, externalDNS)
+
+	statuses := []operatorv1beta1.ExternalDNSZoneStatus{}
+	for seq, zone := range externalDNSMetricsZones(externalDNS) {
+		containerName := controller.ExternalDNSContainerName(zone)
+		status := operatorv1beta1.ExternalDNSZoneStatus{
+			Zone:          zone,
+			ContainerName: containerName,
+		}
+		if prev, ok := previous[containerName]; ok && prev.Zone == zone {
+			status.LastSyncTime = prev.LastSyncTime
+			status.ManagedRecords = prev.ManagedRecords
+		}
+
+		if podsErr != nil {
+			status.LastError = fmt.Sprintf("failed to get ExternalDNS pod: %v", podsErr)
+			statuses = append(statuses, status)
+			continue
+		}
+		if len(pods) == 0 {
+			status.LastError = "no running ExternalDNS pod found"
+			statuses = append(statuses, status)
+			continue
+		}
+		// the single replica publishes all the zones
+		pod := &pods[0]
+
+		status.LastError = containerError(pod, containerName)
+
+		if notScrapedError != "" {
+			if status.LastError == "" {
+				status.LastError = notScrapedError
+			}
+		} else {
+			url := fmt.Sprintf("https://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(metricsProxyStartPort+seq)), metricsPath)
+			serverName := fmt.Sprintf("%s.%s.svc", serviceName.Name, serviceName.Namespace)
+			if metrics, err := scrape(ctx, url, serverName); err != nil {
+				if status.LastError == "" {
+					status.LastError = truncateZoneError(fmt.Sprintf("failed to scrape the metrics: %v", err))
+				}
+			} else if err := fillZoneStatusFromMetrics(&status, metrics); err != nil && status.LastError == "" {
+				status.LastError = truncateZoneError(fmt.Sprintf("failed to parse the metrics: %v", err))
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// runningExternalDNSPods returns the running pods of the given deployment sorted by name.
+func runningExternalDNSPods(ctx context.Context, cl client.Client, deployment *appsv1.Deployment) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := getFilteredPodsList(ctx, cl, deployment.Namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	running := []corev1.Pod{}
+	for i := range pods {
+		if pods[i].Status.Phase == corev1.PodRunning && pods[i].DeletionTimestamp == nil && pods[i].Status.PodIP != "" {
+			running = append(running, pods[i])
+		}
+	}
+	return running, nil
+}
+
+// containerError returns the error which prevents the given container from running.
+// Returns an empty string if the container is running.
+func containerError(pod *corev1.Pod, containerName string) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != containerName {
+			continue
+		}
+		if cs.State.Waiting == nil || cs.State.Waiting.Reason == "" {
+			return ""
+		}
+		message := cs.State.Waiting.Reason
+		if cs.LastTerminationState.Terminated != nil && cs.LastTerminationState.Terminated.Message != "" {
+			message += ": " + strings.TrimSpace(cs.LastTerminationState.Terminated.Message)
+		}
+		return truncateZoneError(message)
+	}
+	return ""
+}
+
+// fillZoneStatusFromMetrics fills the given zone status with the values of the given metrics.
+func fillZoneStatusFromMetrics(status *operatorv1beta1.ExternalDNSZoneStatus, metrics string) error {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(metrics))
+	if err != nil {
+		return err
+	}
+
+	if mf, ok := families[lastSyncTimestampMetric]; ok && len(mf.GetMetric()) > 0 {
+		// the timestamp is unset until the first successful synchronization
+		if ts := mf.GetMetric()[0].GetGauge().GetValue(); ts > 0 {
+			// the status keeps the time with the precision of seconds:
+			// the fractions would trigger the status updates on every reconciliation
+			lastSync := metav1.NewTime(time.Unix(int64(ts), 0))
+			status.LastSyncTime = &lastSync
+		}
+	}
+
+	if mf, ok := families[registryEndpointsMetric]; ok && len(mf.GetMetric()) > 0 {
+		records := int64(mf.GetMetric()[0].GetGauge().GetValue())
+		status.ManagedRecords = &records
+	}
+
+	return nil
+}
+
+// truncateZoneError truncates the given error to fit the zone status.
+func truncateZoneError(message string) string {
+	if len(message) > maxZoneErrorLength {
+		return message[:maxZoneErrorLength] + "..."
+	}
+	return message
+}
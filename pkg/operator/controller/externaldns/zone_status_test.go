@@ -0,0 +1,174 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaldnscontroller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
+	controller "github.com/openshift/external-dns-operator/pkg/operator/controller"
+	"github.com/openshift/external-dns-operator/pkg/operator/controller/utils/test"
+)
+
+const testZoneMetrics = `# HELP external_dns_controller_last_sync_timestamp_seconds Timestamp of last successful sync with the DNS provider
+# TYPE external_dns_controller_last_sync_timestamp_seconds gauge
+external_dns_controller_last_sync_timestamp_seconds 1.7045856e+09
+# HELP external_dns_registry_endpoints_total Number of Endpoints in the registry
+# TYPE external_dns_registry_endpoints_total gauge
+external_dns_registry_endpoints_total 12
+`
+
+func TestComputeZoneStatuses(t *testing.T) {
+	publicContainer := controller.ExternalDNSContainerName(test.PublicZone)
+	privateContainer := controller.ExternalDNSContainerName(test.PrivateZone)
+	lastSync := metav1.NewTime(time.Unix(1704585600, 0))
+	previousSync := metav1.NewTime(time.Unix(1704500000, 0))
+
+	testCases := []struct {
+		name             string
+		exposure         operatorv1beta1.ExternalDNSMetricsExposure
+		previousStatuses []operatorv1beta1.ExternalDNSZoneStatus
+		existingPods     []runtime.Object
+		scrape           scrapeMetricsFunc
+		notOpenShift     bool
+		expectedURLs     []string
+		expectedStatuses []operatorv1beta1.ExternalDNSZoneStatus
+	}{
+		{
+			name:         "Local metrics",
+			existingPods: []runtime.Object{testZonePod("pod", corev1.PodRunning)},
+			scrape:       fakeScrapeMetrics(testZoneMetrics, nil, nil),
+			expectedStatuses: []operatorv1beta1.ExternalDNSZoneStatus{
+				{Zone: test.PublicZone, ContainerName: publicContainer, LastError: metricsNotExposedError},
+				{Zone: test.PrivateZone, ContainerName: privateContainer, LastError: metricsNotExposedError},
+			},
+		},
+		{
+			name:         "Metrics exposed by kube-rbac-proxy outside of OpenShift",
+			exposure:     operatorv1beta1.MetricsExposureKubeRBACProxy,
+			notOpenShift: true,
+			previousStatuses: []operatorv1beta1.ExternalDNSZoneStatus{
+				{Zone: test.PublicZone, ContainerName: publicContainer, LastSyncTime: &previousSync, ManagedRecords: ptr.To[int64](3)},
+			},
+			existingPods: []runtime.Object{testZonePod("pod", corev1.PodRunning)},
+			expectedStatuses: []operatorv1beta1.ExternalDNSZoneStatus{
+				{Zone: test.PublicZone, ContainerName: publicContainer, LastSyncTime: &previousSync, ManagedRecords: ptr.To[int64](3), LastError: metricsNotVerifiableError},
+				{Zone: test.PrivateZone, ContainerName: privateContainer, LastError: metricsNotVerifiableError},
+			},
+		},
+		{
+			name:         "Metrics exposed by kube-rbac-proxy",
+			exposure:     operatorv1beta1.MetricsExposureKubeRBACProxy,
+			existingPods: []runtime.Object{testZonePod("pod-b", corev1.PodRunning), testZonePod("pod-a", corev1.PodPending)},
+			expectedURLs: []string{"https://10.0.0.1:8443/metrics", "https://10.0.0.1:8444/metrics"},
+			expectedStatuses: []operatorv1beta1.ExternalDNSZoneStatus{
+				{Zone: test.PublicZone, ContainerName: publicContainer, LastSyncTime: &lastSync, ManagedRecords: ptr.To[int64](12)},
+				{Zone: test.PrivateZone, ContainerName: privateContainer, LastSyncTime: &lastSync, ManagedRecords: ptr.To[int64](12)},
+			},
+		},
+		{
+			name:     "Failed scrape keeps last known values",
+			exposure: operatorv1beta1.MetricsExposureKubeRBACProxy,
+			previousStatuses: []operatorv1beta1.ExternalDNSZoneStatus{
+				{Zone: test.PublicZone, ContainerName: publicContainer, LastSyncTime: &previousSync, ManagedRecords: ptr.To[int64](3)},
+			},
+			existingPods: []runtime.Object{testZonePod("pod", corev1.PodRunning)},
+			scrape:       fakeScrapeMetrics("", errors.New(`unexpected response status "403 Forbidden"`), nil),
+			expectedStatuses: []operatorv1beta1.ExternalDNSZoneStatus{
+				{Zone: test.PublicZone, ContainerName: publicContainer, LastSyncTime: &previousSync, ManagedRecords: ptr.To[int64](3), LastError: `failed to scrape the metrics: unexpected response status "403 Forbidden"`},
+				{Zone: test.PrivateZone, ContainerName: privateContainer, LastError: `failed to scrape the metrics: unexpected response status "403 Forbidden"`},
+			},
+		},
+		{
+			name: "Crashing container",
+			existingPods: []runtime.Object{testZonePod("pod", corev1.PodRunning, corev1.ContainerStatus{
+				Name:                 privateContainer,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: "failed to list hosted zones\n"}},
+			})},
+			expectedStatuses: []operatorv1beta1.ExternalDNSZoneStatus{
+				{Zone: test.PublicZone, ContainerName: publicContainer, LastError: metricsNotExposedError},
+				{Zone: test.PrivateZone, ContainerName: privateContainer, LastError: "CrashLoopBackOff: failed to list hosted zones"},
+			},
+		},
+		{
+			name:         "No running pod",
+			existingPods: []runtime.Object{testZonePod("pod", corev1.PodPending)},
+			expectedStatuses: []operatorv1beta1.ExternalDNSZoneStatus{
+				{Zone: test.PublicZone, ContainerName: publicContainer, LastError: "no running ExternalDNS pod found"},
+				{Zone: test.PrivateZone, ContainerName: privateContainer, LastError: "no running ExternalDNS pod found"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := testAWSExternalDNSZones([]string{test.PublicZone, test.PrivateZone}, operatorv1beta1.SourceTypeService)
+			extDNS.Spec.Metrics.Exposure = tc.exposure
+			extDNS.Status.ZoneStatuses = tc.previousStatuses
+
+			deployment := fakeDeployment(appsv1.DeploymentAvailable, corev1.ConditionTrue, 1, "25%", "25%", 1, "external-dns-operator")
+			deployment.Namespace = test.OperandNamespace
+
+			var gotURLs []string
+			scrape := tc.scrape
+			if tc.notOpenShift {
+				scrape = nil
+			} else if scrape == nil {
+				scrape = fakeScrapeMetrics(testZoneMetrics, nil, &gotURLs)
+			}
+
+			cl := fake.NewClientBuilder().WithScheme(test.Scheme).WithRuntimeObjects(tc.existingPods...).Build()
+			statuses := computeZoneStatuses(context.TODO(), cl, scrape, extDNS, &deployment)
+			if diff := cmp.Diff(tc.expectedStatuses, statuses); diff != "" {
+				t.Errorf("unexpected zone statuses (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.expectedURLs, gotURLs); diff != "" {
+				t.Errorf("unexpected scraped URLs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func testZonePod(name string, phase corev1.PodPhase, statuses ...corev1.ContainerStatus) *corev1.Pod {
+	pod := fakePod(name, test.OperandNamespace, "external-dns-operator", corev1.ConditionTrue, "Scheduled")
+	pod.Status.Phase = phase
+	pod.Status.PodIP = "10.0.0.1"
+	pod.Status.ContainerStatuses = statuses
+	return &pod
+}
+
+func fakeScrapeMetrics(metrics string, err error, urls *[]string) scrapeMetricsFunc {
+	return func(_ context.Context, url, _ string) (string, error) {
+		if urls != nil {
+			*urls = append(*urls, url)
+		}
+		return metrics, err
+	}
+}
@@ -31,13 +31,26 @@ import (
 
 const (
 	// ExternalDNSBaseName is the base name for any ExternalDNS resource.
-	ExternalDNSBaseName                = "external-dns"
-	CredentialsRequestNamespace        = "openshift-cloud-credential-operator"
-	ControllerName                     = "external_dns_controller"
+	ExternalDNSBaseName         = "external-dns"
+	CredentialsRequestNamespace = "openshift-cloud-credential-operator"
+	ControllerName              = "external_dns_controller"
+	// SecretFromCloudCredentialsOperator is the base name of the secrets provisioned by the cloud credentials operator.
 	SecretFromCloudCredentialsOperator = "externaldns-cloud-credentials"
 	ServiceAccountName                 = "external-dns-operator"
+	// ExternalDNSOperandLabel is the label set on the resources created in the operand namespaces.
+	ExternalDNSOperandLabel = "externaldns.olm.openshift.io/operand"
+	// ExternalDNSCleanupFinalizer is the finalizer which holds the deletion of ExternalDNS instance
+	// until the DNS records managed by the instance are cleaned up.
+	ExternalDNSCleanupFinalizer = "externaldns.olm.openshift.io/cleanup-records"
+	// GCPWorkloadIdentityTokenMountPath is the directory of the service account token projected for GCP workload identity federation.
+	// The external account credential config rendered into the operand credentials secret points at the token file.
+	GCPWorkloadIdentityTokenMountPath = "/var/run/secrets/gcp/serviceaccount"
+	// GCPWorkloadIdentityTokenPath is the name of the token file in GCPWorkloadIdentityTokenMountPath.
+	GCPWorkloadIdentityTokenPath = "token"
 )
 
+// ExternalDNSCredentialsRequestName returns the namespaced name of the credentials request
+// which provisions the cloud credentials for the given ExternalDNS instance.
 func ExternalDNSCredentialsRequestName(externalDNS *operatorv1beta1.ExternalDNS) types.NamespacedName {
 	return types.NamespacedName{
 		// CCO recommendation for the core operators (for which it was primarily designed for) is to use CCO namespace:
@@ -47,16 +60,71 @@ func ExternalDNSCredentialsRequestName(externalDNS *operatorv1beta1.ExternalDNS)
 		// However, there are plans to restrict the credentials request watch to CCO namespace only:
 		// https://github.com/openshift/cloud-credential-operator/blob/611939bce7694d5b1128cb3e569d794f8cba06a1/pkg/operator/credentialsrequest/credentialsrequest_controller.go#L127-L128
 		// So the recommendation from the CCO engineering was to stick to CCO namespace.
+		Namespace: CredentialsRequestNamespace,
+		Name:      ExternalDNSResourceName(externalDNS),
+	}
+}
+
+// ExternalDNSLegacyCredentialsRequestName returns the namespaced name of the credentials request
+// which used to be shared by all the ExternalDNS instances of the same provider type.
+func ExternalDNSLegacyCredentialsRequestName(externalDNS *operatorv1beta1.ExternalDNS) types.NamespacedName {
+	return types.NamespacedName{
 		Namespace: CredentialsRequestNamespace,
 		Name:      "externaldns-credentials-request-" + strings.ToLower(string(externalDNS.Spec.Provider.Type)),
 	}
 }
 
+// ExternalDNSCloudCredentialsSecretName returns the name of the secret
+// provisioned by the cloud credentials operator for the given ExternalDNS instance.
+func ExternalDNSCloudCredentialsSecretName(externalDNS *operatorv1beta1.ExternalDNS) string {
+	return SecretFromCloudCredentialsOperator + "-" + externalDNS.Name
+}
+
 // ExternalDNSResourceName returns the name for the resources unique for the given ExternalDNS instance.
 func ExternalDNSResourceName(externalDNS *operatorv1beta1.ExternalDNS) string {
 	return ExternalDNSBaseName + "-" + externalDNS.Name
 }
 
+// ExternalDNSCleanupJobName returns the name of the job which cleans up the DNS records of the given ExternalDNS instance.
+func ExternalDNSCleanupJobName(externalDNS *operatorv1beta1.ExternalDNS) string {
+	return ExternalDNSResourceName(externalDNS) + "-cleanup"
+}
+
+// ExternalDNSPlanConfigMapName returns the namespaced name of the configmap
+// which lists the changes planned by the given ExternalDNS instance in the dry run mode.
+func ExternalDNSPlanConfigMapName(operandNamespace string, externalDNS *operatorv1beta1.ExternalDNS) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: operandNamespace,
+		Name:      ExternalDNSResourceName(externalDNS) + "-plan",
+	}
+}
+
+// ExternalDNSMetricsServiceName returns the namespaced name of the service
+// which exposes the metrics of the given ExternalDNS instance.
+// The secret with the serving certificate of the metrics has the same name.
+func ExternalDNSMetricsServiceName(operandNamespace string, externalDNS *operatorv1beta1.ExternalDNS) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: operandNamespace,
+		Name:      ExternalDNSResourceName(externalDNS) + "-metrics",
+	}
+}
+
+// ExternalDNSOperandNamespace returns the namespace of the operand resources of the given ExternalDNS instance,
+// the given default namespace is used if the instance doesn't specify any.
+func ExternalDNSOperandNamespace(externalDNS *operatorv1beta1.ExternalDNS, defaultNamespace string) string {
+	if externalDNS.Spec.OperandNamespace != "" {
+		return externalDNS.Spec.OperandNamespace
+	}
+	return defaultNamespace
+}
+
+// ExternalDNSOperandLabels returns the labels which are set on the operand resources.
+func ExternalDNSOperandLabels() map[string]string {
+	return map[string]string{
+		ExternalDNSOperandLabel: "true",
+	}
+}
+
 // ExternalDNSGlobalResourceName returns the name for the resources shared among ExternalDNS instances.
 func ExternalDNSGlobalResourceName() string {
 	return ExternalDNSBaseName
@@ -111,6 +179,18 @@ func ExternalDNSCredentialsSecretNameFromProvider(externalDNS *operatorv1beta1.E
 		if externalDNS.Spec.Provider.Infoblox != nil {
 			return externalDNS.Spec.Provider.Infoblox.Credentials.Name
 		}
+	case operatorv1beta1.ProviderTypeCloudflare:
+		if externalDNS.Spec.Provider.Cloudflare != nil {
+			return externalDNS.Spec.Provider.Cloudflare.Credentials.Name
+		}
+	case operatorv1beta1.ProviderTypeRFC2136:
+		if externalDNS.Spec.Provider.RFC2136 != nil {
+			return externalDNS.Spec.Provider.RFC2136.Credentials.Name
+		}
+	case operatorv1beta1.ProviderTypeWebhook:
+		if externalDNS.Spec.Provider.Webhook != nil {
+			return externalDNS.Spec.Provider.Webhook.Credentials.Name
+		}
 	}
 	return ""
 }
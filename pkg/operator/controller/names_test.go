@@ -18,6 +18,8 @@ package externaldnscontroller
 
 import (
 	"testing"
+
+	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
 )
 
 func TestExternalDNSContainerName(t *testing.T) {
@@ -57,3 +59,35 @@ func TestExternalDNSContainerName(t *testing.T) {
 		})
 	}
 }
+
+func TestExternalDNSOperandNamespace(t *testing.T) {
+	testCases := []struct {
+		name             string
+		operandNamespace string
+		expect           string
+	}{
+		{
+			name:   "Default namespace",
+			expect: "external-dns",
+		},
+		{
+			name:             "Namespace from spec",
+			operandNamespace: "tenant-a",
+			expect:           "tenant-a",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			extDNS := &operatorv1beta1.ExternalDNS{
+				Spec: operatorv1beta1.ExternalDNSSpec{
+					OperandNamespace: tc.operandNamespace,
+				},
+			}
+			got := ExternalDNSOperandNamespace(extDNS, "external-dns")
+			if got != tc.expect {
+				t.Errorf("expect %s operand namespace, got %s", tc.expect, got)
+			}
+		})
+	}
+}
@@ -33,3 +33,11 @@ func InNamespace(namespace string) func(o client.Object) bool {
 		return o.GetNamespace() == namespace
 	}
 }
+
+// HasLabel returns a predicate which checks whether an object has the given label.
+func HasLabel(label string) func(o client.Object) bool {
+	return func(o client.Object) bool {
+		_, found := o.GetLabels()[label]
+		return found
+	}
+}
@@ -104,3 +104,43 @@ func TestInNamespace(t *testing.T) {
 		})
 	}
 }
+
+func TestHasLabel(t *testing.T) {
+	testCases := []struct {
+		name     string
+		label    string
+		secret   client.Object
+		expected bool
+	}{
+		{
+			name:  "Has label",
+			label: "testlabel",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test",
+					Labels: map[string]string{"testlabel": "true"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name:  "Does not have label",
+			label: "testlabel",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "test",
+					Labels: map[string]string{"otherlabel": "true"},
+				},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := HasLabel(tc.label)(tc.secret); got != tc.expected {
+				t.Errorf("unexpected return value received. expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
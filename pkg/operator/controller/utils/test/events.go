@@ -24,6 +24,7 @@ import (
 	cco "github.com/openshift/cloud-credential-operator/pkg/apis/cloudcredential/v1"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
@@ -59,6 +60,10 @@ func NewEvent(we watch.Event) Event {
 		te.ObjType = "deployment"
 		te.Namespace = obj.Namespace
 		te.Name = obj.Name
+	case *batchv1.Job:
+		te.ObjType = "job"
+		te.Namespace = obj.Namespace
+		te.Name = obj.Name
 	case *corev1.ServiceAccount:
 		te.ObjType = "serviceaccount"
 		te.Namespace = obj.Namespace
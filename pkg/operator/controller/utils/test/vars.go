@@ -32,6 +32,7 @@ const (
 	OperandNamespace       = "external-dns"
 	OperandName            = "external-dns-test"
 	OperandImage           = "quay.io/test/external-dns:latest"
+	MetricsProxyImage      = "quay.io/test/kube-rbac-proxy:latest"
 	OperatorNamespace      = "external-dns-operator"
 	OperandSecretName      = "external-dns-credentials-test"
 	PublicZone             = "my-dns-public-zone"
@@ -21,6 +21,7 @@ import (
 	"crypto/tls"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -49,10 +50,21 @@ type Operator struct {
 // +kubebuilder:rbac:groups=cloudcredential.openshift.io,resources=credentialsrequests;credentialsrequests/status;credentialsrequests/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;watch;list
 // +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // local role
 // +kubebuilder:rbac:groups="",namespace=external-dns-operator,resources=secrets;serviceaccounts;configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="apps",namespace=external-dns-operator,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",namespace=external-dns-operator,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=external-dns-operator,resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="batch",namespace=external-dns-operator,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",namespace=external-dns-operator,resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,namespace=external-dns-operator,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+// operand namespaces given in ExternalDNS spec:
+// the namespaced permissions are granted by the admin in every allowed namespace,
+// see config/rbac/operand_namespace_role.yaml
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterrolebindings,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=bind,resourceNames=external-dns
+// +kubebuilder:rbac:urls=/metrics,verbs=get
 
 // New creates a new operator from cliCfg and opCfg.
 func New(cliCfg *rest.Config, opCfg *operatorconfig.Config) (*Operator, error) {
@@ -74,10 +86,7 @@ func New(cliCfg *rest.Config, opCfg *operatorconfig.Config) (*Operator, error) {
 		},
 		HealthProbeBindAddress: opCfg.HealthProbeBindAddress,
 		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				opCfg.OperatorNamespace: {},
-				opCfg.OperandNamespace:  {},
-			},
+			DefaultNamespaces: cacheNamespaces(opCfg),
 		},
 		// Use a non-caching client everywhere. The default split client does not
 		// promise to invalidate the cache during writes (nor does it promise
@@ -102,7 +111,7 @@ func New(cliCfg *rest.Config, opCfg *operatorconfig.Config) (*Operator, error) {
 	}
 
 	if opCfg.EnableWebhook {
-		if err = (&operatorv1beta1.ExternalDNS{}).SetupWebhookWithManager(mgr, opCfg.IsOpenShift); err != nil {
+		if err = (&operatorv1beta1.ExternalDNS{}).SetupWebhookWithManager(mgr, opCfg.IsOpenShift, append([]string{opCfg.OperandNamespace}, opCfg.AllowedOperandNamespaces...)); err != nil {
 			return nil, fmt.Errorf("unable to setup webhook for ExternalDNS: %w", err)
 		}
 	}
@@ -119,10 +128,13 @@ func New(cliCfg *rest.Config, opCfg *operatorconfig.Config) (*Operator, error) {
 	// Create and register the externaldns controller with the operator manager.
 	if _, err := externaldnsctrl.New(mgr, externaldnsctrl.Config{
 		Namespace:         opCfg.OperandNamespace,
+		AllowedNamespaces: opCfg.AllowedOperandNamespaces,
 		Image:             opCfg.ExternalDNSImage,
+		MetricsProxyImage: opCfg.KubeRBACProxyImage,
 		OperatorNamespace: opCfg.OperatorNamespace,
 		IsOpenShift:       opCfg.IsOpenShift,
 		PlatformStatus:    opCfg.PlatformStatus,
+		NativeSidecars:    opCfg.NativeSidecars,
 		InjectTrustedCA:   opCfg.InjectTrustedCA(),
 		RequeuePeriod:     opCfg.RequeuePeriod(),
 	}); err != nil {
@@ -158,3 +170,20 @@ func New(cliCfg *rest.Config, opCfg *operatorconfig.Config) (*Operator, error) {
 func (o *Operator) Start(ctx context.Context) error {
 	return o.manager.Start(ctx)
 }
+
+// cacheNamespaces returns the namespaces watched by the operator.
+// Only the resources created by the operator are cached in the allowed operand namespaces.
+func cacheNamespaces(opCfg *operatorconfig.Config) map[string]cache.Config {
+	namespaces := map[string]cache.Config{
+		opCfg.OperatorNamespace: {},
+		opCfg.OperandNamespace:  {},
+	}
+	for _, ns := range opCfg.AllowedOperandNamespaces {
+		if _, found := namespaces[ns]; !found {
+			namespaces[ns] = cache.Config{
+				LabelSelector: labels.SelectorFromSet(operatorctrl.ExternalDNSOperandLabels()),
+			}
+		}
+	}
+	return namespaces
+}
@@ -22,8 +22,12 @@ import (
 	operatorv1beta1 "github.com/openshift/external-dns-operator/api/v1beta1"
 )
 
-// ManagedCredentialsProvider returns true if the credentials of the ExternalDNS provider can be managed by the platform
+// ManagedCredentialsProvider returns true if the credentials of the ExternalDNS provider can be managed by the platform.
+// The workload identity doesn't need any credentials to be managed.
 func ManagedCredentialsProvider(e *operatorv1beta1.ExternalDNS) bool {
+	if WorkloadIdentityProvider(e) {
+		return false
+	}
 	switch e.Spec.Provider.Type {
 	case operatorv1beta1.ProviderTypeAWS, operatorv1beta1.ProviderTypeGCP, operatorv1beta1.ProviderTypeAzure:
 		return true
@@ -31,10 +35,38 @@ func ManagedCredentialsProvider(e *operatorv1beta1.ExternalDNS) bool {
 	return false
 }
 
+// WorkloadIdentityProvider returns true if the ExternalDNS provider authenticates
+// with the projected service account token of the pod instead of the credentials secret
+func WorkloadIdentityProvider(e *operatorv1beta1.ExternalDNS) bool {
+	switch e.Spec.Provider.Type {
+	case operatorv1beta1.ProviderTypeAWS:
+		return e.Spec.Provider.AWS != nil && e.Spec.Provider.AWS.CredentialsMode == operatorv1beta1.AWSCredentialsModeWebIdentity
+	case operatorv1beta1.ProviderTypeAzure:
+		return e.Spec.Provider.Azure != nil && e.Spec.Provider.Azure.CredentialsMode == operatorv1beta1.AzureCredentialsModeWorkloadIdentity
+	case operatorv1beta1.ProviderTypeGCP:
+		return e.Spec.Provider.GCP != nil && (e.Spec.Provider.GCP.CredentialsMode == operatorv1beta1.GCPCredentialsModeWorkloadIdentityFederation ||
+			e.Spec.Provider.GCP.CredentialsMode == operatorv1beta1.GCPCredentialsModeGKEWorkloadIdentity)
+	}
+	return false
+}
+
+// WorkloadIdentityConfigProvider returns true if the workload identity of the ExternalDNS provider
+// is configured by the config file which the operator renders into the operand credentials secret
+func WorkloadIdentityConfigProvider(e *operatorv1beta1.ExternalDNS) bool {
+	switch e.Spec.Provider.Type {
+	case operatorv1beta1.ProviderTypeAzure:
+		return WorkloadIdentityProvider(e)
+	case operatorv1beta1.ProviderTypeGCP:
+		return e.Spec.Provider.GCP != nil && e.Spec.Provider.GCP.CredentialsMode == operatorv1beta1.GCPCredentialsModeWorkloadIdentityFederation
+	}
+	return false
+}
+
 // EnvProxySupportedProvider returns true if the ExternalDNS provider supports the proxy settings via environment variables HTTP(S)_PROXY, NO_PROXY
 func EnvProxySupportedProvider(e *operatorv1beta1.ExternalDNS) bool {
 	switch e.Spec.Provider.Type {
-	case operatorv1beta1.ProviderTypeAWS, operatorv1beta1.ProviderTypeAzure, operatorv1beta1.ProviderTypeGCP, operatorv1beta1.ProviderTypeInfoblox:
+	case operatorv1beta1.ProviderTypeAWS, operatorv1beta1.ProviderTypeAzure, operatorv1beta1.ProviderTypeGCP, operatorv1beta1.ProviderTypeInfoblox, operatorv1beta1.ProviderTypeCloudflare,
+		operatorv1beta1.ProviderTypeWebhook:
 		return true
 	}
 	return false